@@ -1,3 +1,3 @@
 module github.com/localyyz/go-shopify
 
-go 1.12
+go 1.13
@@ -10,7 +10,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"strconv"
 	"time"
 )
 
@@ -146,6 +145,37 @@ type PaymentRequest struct {
 
 const StripeVaultToken = `stripe_vault_token`
 
+// CheckoutCompleteError is returned when completing a checkout did not
+// create an order. If the last payment of the checkout failed, it wraps the
+// *PaymentError.
+type CheckoutCompleteError struct {
+	Token    string
+	Checkout *Checkout
+
+	err error
+}
+
+func newCheckoutCompleteError(token string, checkout *Checkout) *CheckoutCompleteError {
+	e := &CheckoutCompleteError{Token: token, Checkout: checkout}
+	if checkout != nil && len(checkout.Payments) > 0 {
+		p := checkout.Payments[len(checkout.Payments)-1]
+		e.err = transactionError(p.Transaction, p.PaymentProcessingErrorMessage)
+	}
+	return e
+}
+
+func (e *CheckoutCompleteError) Error() string {
+	if e.err != nil {
+		return fmt.Sprintf("checkout %s not completed: %v", e.Token, e.err)
+	}
+	return fmt.Sprintf("checkout %s not completed", e.Token)
+}
+
+// Unwrap returns the *PaymentError of the last payment, if any.
+func (e *CheckoutCompleteError) Unwrap() error {
+	return e.err
+}
+
 func (c *CheckoutService) Get(ctx context.Context, token string) (*Checkout, *http.Response, error) {
 	req, err := c.client.NewRequest("GET", fmt.Sprintf("/admin/checkouts/%s.json", token), nil)
 	if err != nil {
@@ -184,6 +214,10 @@ func (c *CheckoutService) Create(ctx context.Context, checkout *Checkout) (*Chec
 	if err != nil {
 		return nil, resp, err
 	}
+	resp, err = c.client.poll(ctx, resp, checkoutWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
 
 	return checkoutWrapper.Checkout, resp, nil
 }
@@ -205,94 +239,83 @@ func (c *CheckoutService) Update(ctx context.Context, checkout *Checkout) (*Chec
 	if err != nil {
 		return nil, resp, err
 	}
+	resp, err = c.client.poll(ctx, resp, checkoutWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
 
 	return checkoutWrapper.Checkout, resp, nil
 }
 
 func (c *CheckoutService) ListShippingRates(ctx context.Context, token string) ([]*CheckoutShipping, *http.Response, error) {
-	var (
-		resp                *http.Response
-		pollWait            = "0"
-		pollURL             = fmt.Sprintf("/admin/checkouts/%s/shipping_rates.json", token)
-		pollStatus          = http.StatusAccepted
-		shippingRateWrapper = new(ShippingRateRequest)
-	)
-
-	for {
-		if pollStatus != http.StatusAccepted {
-			break
-		}
-
-		req, err := c.client.NewRequest("GET", pollURL, nil)
-		if err != nil {
-			return nil, nil, err
-		}
-
-		wait, _ := strconv.Atoi(pollWait)
-		// TODO: make a proper poller
-		time.Sleep(time.Duration(wait) * time.Second)
-
-		resp, err = c.client.Do(ctx, req, shippingRateWrapper)
-		if err != nil {
-			return nil, resp, err
-		}
+	req, err := c.client.NewRequest("GET", fmt.Sprintf("/admin/checkouts/%s/shipping_rates.json", token), nil)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		// check Location and Retry-After for url and delay
-		pollURL = resp.Header.Get("Location")
-		pollWait = resp.Header.Get("Retry-After")
-		pollStatus = resp.StatusCode
+	shippingRateWrapper := new(ShippingRateRequest)
+	resp, err := c.client.Do(ctx, req, shippingRateWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+	// shipping rates are computed in the background
+	resp, err = c.client.poll(ctx, resp, shippingRateWrapper)
+	if err != nil {
+		return nil, resp, err
 	}
 
 	return shippingRateWrapper.CheckoutShipping, resp, nil
 }
 
 func (c *CheckoutService) Payment(ctx context.Context, token string, payment *Payment) (*Payment, *http.Response, error) {
-	var (
-		resp           *http.Response
-		paymentWrapper = &PaymentRequest{payment}
-	)
-
+	paymentWrapper := &PaymentRequest{payment}
 	req, err := c.client.NewRequest("POST", fmt.Sprintf("/admin/checkouts/%s/payments.json", token), paymentWrapper)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	resp, err = c.client.Do(ctx, req, paymentWrapper)
+	resp, err := c.client.Do(ctx, req, paymentWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+	resp, err = c.client.poll(ctx, resp, paymentWrapper)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	var (
-		pollURL    = resp.Header.Get("Location")
-		pollWait   = resp.Header.Get("Retry-After")
-		pollStatus = resp.StatusCode
-	)
-
-	// poll and wait
-	for {
-		if pollStatus != http.StatusAccepted {
-			break
+	if p := paymentWrapper.Payment; p != nil {
+		if err := transactionError(p.Transaction, p.PaymentProcessingErrorMessage); err != nil {
+			return p, resp, err
 		}
+	}
 
-		req, err = c.client.NewRequest("GET", pollURL, nil)
-		if err != nil {
-			return nil, nil, err
-		}
+	return paymentWrapper.Payment, resp, nil
+}
 
-		wait, _ := strconv.Atoi(pollWait)
-		// TODO: make a proper poller
-		time.Sleep(time.Duration(wait) * time.Second)
+// Complete completes a checkout that requires no payment, ie. one paid with
+// gift cards or fully discounted, into an order. The completed checkout holds
+// the OrderID and OrderStatusURL of the order. If no order was created, a
+// *CheckoutCompleteError is returned along with the checkout.
+func (c *CheckoutService) Complete(ctx context.Context, token string) (*Checkout, *http.Response, error) {
+	req, err := c.client.NewRequest("POST", fmt.Sprintf("/admin/checkouts/%s/complete.json", token), struct{}{})
+	if err != nil {
+		return nil, nil, err
+	}
 
-		resp, err = c.client.Do(ctx, req, paymentWrapper)
-		if err != nil {
-			return nil, resp, err
-		}
+	checkoutWrapper := new(CheckoutRequest)
+	resp, err := c.client.Do(ctx, req, checkoutWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+	resp, err = c.client.poll(ctx, resp, checkoutWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
 
-		// check Location and Retry-After for url and delay
-		pollURL = resp.Header.Get("Location")
-		pollWait = resp.Header.Get("Retry-After")
-		pollStatus = resp.StatusCode
+	checkout := checkoutWrapper.Checkout
+	if checkout == nil || checkout.OrderID == 0 {
+		return checkout, resp, newCheckoutCompleteError(token, checkout)
 	}
 
-	return paymentWrapper.Payment, resp, nil
+	return checkout, resp, nil
 }
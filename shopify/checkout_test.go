@@ -0,0 +1,88 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCheckoutComplete(t *testing.T) {
+	t.Parallel()
+
+	inputs := []struct {
+		name     string
+		checkout string
+		orderID  int64
+		expected error
+	}{
+		{
+			name:     "completed",
+			checkout: `{"checkout":{"token":"abc","order_id":1,"order_status_url":"https://x/orders/1"}}`,
+			orderID:  1,
+		},
+		{
+			name: "declined",
+			checkout: `{"checkout":{"token":"abc","payments":[{"id":2,"amount":"10.00","unique_token":"u",` +
+				`"transaction":{"status":"failure","error_code":"card_declined"}}]}}`,
+			expected: ErrCardDeclined,
+		},
+		{
+			name:     "no order",
+			checkout: `{"checkout":{"token":"abc"}}`,
+		},
+	}
+
+	for _, tt := range inputs {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var polled bool
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "POST" && r.URL.Path == "/admin/checkouts/abc/complete.json":
+					w.Header().Set("Location", "/admin/checkouts/abc.json")
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(http.StatusAccepted)
+				case r.Method == "GET" && r.URL.Path == "/admin/checkouts/abc.json":
+					polled = true
+					w.Write([]byte(tt.checkout))
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer ts.Close()
+
+			c, _ := NewClient(nil, ShopURL(ts.URL), Poll(&Poller{MinDelay: time.Millisecond}))
+			checkout, _, err := c.Checkout.Complete(context.Background(), "abc")
+			if !polled {
+				t.Errorf("expected the checkout to be polled")
+			}
+			if tt.orderID != 0 {
+				if err != nil {
+					t.Fatalf("unexpected error %v", err)
+				}
+				if checkout.OrderID != tt.orderID || checkout.OrderStatusURL == "" {
+					t.Errorf("unexpected checkout %+v", checkout)
+				}
+				return
+			}
+
+			var cerr *CheckoutCompleteError
+			if !errors.As(err, &cerr) || cerr.Checkout == nil {
+				t.Fatalf("expected a *CheckoutCompleteError with the checkout, got %v", err)
+			}
+			if tt.expected != nil && !errors.Is(err, tt.expected) {
+				t.Errorf("expected errors.Is %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
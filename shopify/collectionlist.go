@@ -35,8 +35,9 @@ type CollectionListImage struct {
 }
 
 type CollectionListParam struct {
-	Limit int
-	Page  int
+	Limit    int
+	Page     int
+	PageInfo string
 }
 
 const (
@@ -67,9 +68,13 @@ func (p *CollectionListParam) EncodeQuery() string {
 		return ""
 	}
 	v := url.Values{}
-	v.Add("page", fmt.Sprintf("%d", p.Page))
-	v.Add("limit", fmt.Sprintf("%d", p.Limit))
-	return v.Encode()
+	if p.Limit > 0 {
+		v.Add("limit", fmt.Sprintf("%d", p.Limit))
+	}
+	if p.Page > 0 {
+		v.Add("page", fmt.Sprintf("%d", p.Page))
+	}
+	return encodeQuery(v, p.PageInfo)
 }
 
 // list all collections
@@ -92,6 +97,27 @@ func (p *CollectionListService) List(ctx context.Context, params *CollectionList
 	return collectionListWrapper.CollectionListings, resp, nil
 }
 
+// CollectionListIterator iterates over collection listings, see Pagination.
+type CollectionListIterator struct{ iterator }
+
+// Value returns the current collection listing.
+func (it *CollectionListIterator) Value() *CollectionList {
+	v, _ := it.value().(*CollectionList)
+	return v
+}
+
+// Iter returns an iterator over every collection listing.
+func (p *CollectionListService) Iter(params *CollectionListParam) *CollectionListIterator {
+	var pp CollectionListParam
+	if params != nil {
+		pp = *params
+	}
+	return &CollectionListIterator{newIterator(func(ctx context.Context, pageInfo string) (interface{}, *http.Response, error) {
+		pp.PageInfo = pageInfo
+		return p.List(ctx, &pp)
+	})}
+}
+
 // fetch one product by the given collection id
 func (p *CollectionListService) Get(ctx context.Context, ID int64) (*CollectionList, *http.Response, error) {
 	req, err := p.client.NewRequest("GET", fmt.Sprintf("/admin/collection_listings/%d.json", ID), nil)
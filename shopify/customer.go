@@ -0,0 +1,358 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// api reference: https://help.shopify.com/en/api/reference/customers/customer
+
+type CustomerService service
+
+type Customer struct {
+	ID                  int64  `json:"id,omitempty"`
+	Email               string `json:"email,omitempty"`
+	FirstName           string `json:"first_name,omitempty"`
+	LastName            string `json:"last_name,omitempty"`
+	Phone               string `json:"phone,omitempty"`
+	State               string `json:"state,omitempty"`
+	Note                string `json:"note,omitempty"`
+	Tags                string `json:"tags,omitempty"`
+	Currency            string `json:"currency,omitempty"`
+	AcceptsMarketing    bool   `json:"accepts_marketing,omitempty"`
+	VerifiedEmail       bool   `json:"verified_email,omitempty"`
+	TaxExempt           bool   `json:"tax_exempt,omitempty"`
+	MultipassIdentifier string `json:"multipass_identifier,omitempty"`
+
+	OrdersCount   int    `json:"orders_count,omitempty"`
+	TotalSpent    string `json:"total_spent,omitempty"`
+	LastOrderID   int64  `json:"last_order_id,omitempty"`
+	LastOrderName string `json:"last_order_name,omitempty"`
+
+	Addresses      []*CustomerAddress `json:"addresses,omitempty"`
+	DefaultAddress *CustomerAddress   `json:"default_address,omitempty"`
+
+	// Create only
+	Password             string `json:"password,omitempty"`
+	PasswordConfirmation string `json:"password_confirmation,omitempty"`
+	SendEmailInvite      bool   `json:"send_email_invite,omitempty"`
+	SendEmailWelcome     bool   `json:"send_email_welcome,omitempty"`
+
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// CustomerInvite is the account invite sent to a customer. Empty fields use
+// the shop's defaults.
+type CustomerInvite struct {
+	To            string   `json:"to,omitempty"`
+	From          string   `json:"from,omitempty"`
+	Bcc           []string `json:"bcc,omitempty"`
+	Subject       string   `json:"subject,omitempty"`
+	CustomMessage string   `json:"custom_message,omitempty"`
+}
+
+type CustomerParam struct {
+	IDs          []int64
+	Limit        int
+	SinceID      int64
+	CreatedAtMin *time.Time
+	CreatedAtMax *time.Time
+	UpdatedAtMin *time.Time
+	UpdatedAtMax *time.Time
+	Fields       []string
+	PageInfo     string
+}
+
+// CustomerSearchParam searches customers with Shopify's search syntax, ie.
+// "email:bob@example.com" or "country:Canada orders_count:>2".
+type CustomerSearchParam struct {
+	Query string
+	// Order is the field and direction to sort by, ie. "last_order_date DESC"
+	Order    string
+	Limit    int
+	Fields   []string
+	PageInfo string
+}
+
+type CustomerRequest struct {
+	Customer *Customer `json:"customer"`
+}
+
+func (p *CustomerParam) EncodeQuery() string {
+	if p == nil {
+		return ""
+	}
+	v := url.Values{}
+	if p.Limit > 0 {
+		v.Add("limit", fmt.Sprintf("%d", p.Limit))
+	}
+	if len(p.Fields) > 0 {
+		v.Add("fields", strings.Join(p.Fields, ","))
+	}
+	if len(p.IDs) > 0 {
+		v.Add("ids", joinIDs(p.IDs))
+	}
+	if p.SinceID > 0 {
+		v.Add("since_id", fmt.Sprintf("%d", p.SinceID))
+	}
+	if p.CreatedAtMin != nil {
+		v.Add("created_at_min", p.CreatedAtMin.Format(timeFormat))
+	}
+	if p.CreatedAtMax != nil {
+		v.Add("created_at_max", p.CreatedAtMax.Format(timeFormat))
+	}
+	if p.UpdatedAtMin != nil {
+		v.Add("updated_at_min", p.UpdatedAtMin.Format(timeFormat))
+	}
+	if p.UpdatedAtMax != nil {
+		v.Add("updated_at_max", p.UpdatedAtMax.Format(timeFormat))
+	}
+	return encodeQuery(v, p.PageInfo)
+}
+
+func (p *CustomerSearchParam) EncodeQuery() string {
+	if p == nil {
+		return ""
+	}
+	v := url.Values{}
+	if p.Limit > 0 {
+		v.Add("limit", fmt.Sprintf("%d", p.Limit))
+	}
+	if len(p.Fields) > 0 {
+		v.Add("fields", strings.Join(p.Fields, ","))
+	}
+	if p.Query != "" {
+		v.Add("query", p.Query)
+	}
+	if p.Order != "" {
+		v.Add("order", p.Order)
+	}
+	return encodeQuery(v, p.PageInfo)
+}
+
+func (s *CustomerService) List(ctx context.Context, params *CustomerParam) ([]*Customer, *http.Response, error) {
+	return s.list(ctx, "/admin/customers.json", params.EncodeQuery())
+}
+
+// CustomerIterator iterates over customers, see Pagination.
+type CustomerIterator struct{ iterator }
+
+// Value returns the current customer.
+func (it *CustomerIterator) Value() *Customer {
+	v, _ := it.value().(*Customer)
+	return v
+}
+
+// Iter returns an iterator over every customer matching params.
+func (s *CustomerService) Iter(params *CustomerParam) *CustomerIterator {
+	var pp CustomerParam
+	if params != nil {
+		pp = *params
+	}
+	return &CustomerIterator{newIterator(func(ctx context.Context, pageInfo string) (interface{}, *http.Response, error) {
+		pp.PageInfo = pageInfo
+		return s.List(ctx, &pp)
+	})}
+}
+
+// Search returns the customers matching the query of params.
+func (s *CustomerService) Search(ctx context.Context, params *CustomerSearchParam) ([]*Customer, *http.Response, error) {
+	return s.list(ctx, "/admin/customers/search.json", params.EncodeQuery())
+}
+
+// SearchIter returns an iterator over every customer matching the query of
+// params.
+func (s *CustomerService) SearchIter(params *CustomerSearchParam) *CustomerIterator {
+	var pp CustomerSearchParam
+	if params != nil {
+		pp = *params
+	}
+	return &CustomerIterator{newIterator(func(ctx context.Context, pageInfo string) (interface{}, *http.Response, error) {
+		pp.PageInfo = pageInfo
+		return s.Search(ctx, &pp)
+	})}
+}
+
+func (s *CustomerService) list(ctx context.Context, path, query string) ([]*Customer, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.URL.RawQuery = query
+
+	var customerWrapper struct {
+		Customers []*Customer `json:"customers"`
+	}
+	resp, err := s.client.Do(ctx, req, &customerWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return customerWrapper.Customers, resp, nil
+}
+
+func (s *CustomerService) Count(ctx context.Context) (int, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "/admin/customers/count.json", nil)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var customerCount struct {
+		Count int `json:"count"`
+	}
+	resp, err := s.client.Do(ctx, req, &customerCount)
+	if err != nil {
+		return 0, resp, err
+	}
+
+	return customerCount.Count, resp, nil
+}
+
+func (s *CustomerService) Get(ctx context.Context, ID int64) (*Customer, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("/admin/customers/%d.json", ID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	customerWrapper := new(CustomerRequest)
+	resp, err := s.client.Do(ctx, req, customerWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return customerWrapper.Customer, resp, nil
+}
+
+func (s *CustomerService) Create(ctx context.Context, customer *Customer) (*Customer, *http.Response, error) {
+	return s.save(ctx, "POST", "/admin/customers.json", &CustomerRequest{customer})
+}
+
+// CustomerUpdate is a partial update of a customer. Only the fields that are
+// set are sent, see VariantUpdate:
+//
+//	client.Customer.Update(ctx, &shopify.CustomerUpdate{
+//		ID:               customerID,
+//		AcceptsMarketing: shopify.Bool(false),
+//	})
+type CustomerUpdate struct {
+	ID                  int64       `json:"id"`
+	Email               *NullString `json:"email,omitempty"`
+	Phone               *NullString `json:"phone,omitempty"`
+	FirstName           *string     `json:"first_name,omitempty"`
+	LastName            *string     `json:"last_name,omitempty"`
+	Note                *NullString `json:"note,omitempty"`
+	Tags                *string     `json:"tags,omitempty"`
+	AcceptsMarketing    *bool       `json:"accepts_marketing,omitempty"`
+	VerifiedEmail       *bool       `json:"verified_email,omitempty"`
+	TaxExempt           *bool       `json:"tax_exempt,omitempty"`
+	MultipassIdentifier *NullString `json:"multipass_identifier,omitempty"`
+
+	Password             *string `json:"password,omitempty"`
+	PasswordConfirmation *string `json:"password_confirmation,omitempty"`
+}
+
+// Update updates the customer with the fields that are set.
+func (s *CustomerService) Update(ctx context.Context, customer *CustomerUpdate) (*Customer, *http.Response, error) {
+	body := struct {
+		Customer *CustomerUpdate `json:"customer"`
+	}{customer}
+	return s.save(ctx, "PUT", fmt.Sprintf("/admin/customers/%d.json", customer.ID), &body)
+}
+
+func (s *CustomerService) save(ctx context.Context, method, path string, body interface{}) (*Customer, *http.Response, error) {
+	req, err := s.client.NewRequest(method, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	customerWrapper := new(CustomerRequest)
+	resp, err := s.client.Do(ctx, req, customerWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return customerWrapper.Customer, resp, nil
+}
+
+// Delete deletes the customer. Customers with orders can't be deleted.
+func (s *CustomerService) Delete(ctx context.Context, ID int64) (*http.Response, error) {
+	req, err := s.client.NewRequest("DELETE", fmt.Sprintf("/admin/customers/%d.json", ID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
+
+// Orders returns the orders of the customer. By default only open orders are
+// returned, set params.Status to OrderStatusAny for all of them.
+func (s *CustomerService) Orders(ctx context.Context, ID int64, params *OrderParam) ([]*Order, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("/admin/customers/%d/orders.json", ID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.URL.RawQuery = params.EncodeQuery()
+
+	var orderWrapper struct {
+		Orders []*Order `json:"orders"`
+	}
+	resp, err := s.client.Do(ctx, req, &orderWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return orderWrapper.Orders, resp, nil
+}
+
+// SendInvite sends an account invite to the customer. A nil invite sends the
+// shop's default invite.
+func (s *CustomerService) SendInvite(ctx context.Context, ID int64, invite *CustomerInvite) (*CustomerInvite, *http.Response, error) {
+	if invite == nil {
+		invite = &CustomerInvite{}
+	}
+	type customerInviteRequest struct {
+		CustomerInvite *CustomerInvite `json:"customer_invite"`
+	}
+	req, err := s.client.NewRequest("POST", fmt.Sprintf("/admin/customers/%d/send_invite.json", ID), &customerInviteRequest{invite})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	inviteWrapper := new(customerInviteRequest)
+	resp, err := s.client.Do(ctx, req, inviteWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return inviteWrapper.CustomerInvite, resp, nil
+}
+
+// AccountActivationURL returns a one time url for the customer to activate
+// their account, without sending an invite. Only customers whose account is
+// not enabled yet can be activated.
+func (s *CustomerService) AccountActivationURL(ctx context.Context, ID int64) (string, *http.Response, error) {
+	req, err := s.client.NewRequest("POST", fmt.Sprintf("/admin/customers/%d/account_activation_url.json", ID), struct{}{})
+	if err != nil {
+		return "", nil, err
+	}
+
+	var urlWrapper struct {
+		AccountActivationURL string `json:"account_activation_url"`
+	}
+	resp, err := s.client.Do(ctx, req, &urlWrapper)
+	if err != nil {
+		return "", resp, err
+	}
+
+	return urlWrapper.AccountActivationURL, resp, nil
+}
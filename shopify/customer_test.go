@@ -0,0 +1,312 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCustomerSearchParam(t *testing.T) {
+	t.Parallel()
+
+	inputs := []struct {
+		name     string
+		params   *CustomerSearchParam
+		expected string
+	}{
+		{"nil", nil, ""},
+		{
+			"query",
+			&CustomerSearchParam{Query: "country:Canada orders_count:>2", Order: "last_order_date DESC", Limit: 50},
+			"limit=50&order=last_order_date+DESC&query=country%3ACanada+orders_count%3A%3E2",
+		},
+		{
+			"cursor",
+			&CustomerSearchParam{Query: "bob", Limit: 50, PageInfo: "abc"},
+			"limit=50&page_info=abc",
+		},
+	}
+
+	for _, tt := range inputs {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if q := tt.params.EncodeQuery(); q != tt.expected {
+				t.Errorf("expected %q got %q", tt.expected, q)
+			}
+		})
+	}
+}
+
+func TestCustomerDeleteAddresses(t *testing.T) {
+	t.Parallel()
+
+	c, last, teardown := setup(http.StatusOK, `{}`)
+	defer teardown()
+
+	if _, err := c.Customer.DeleteAddresses(context.Background(), 1, []int64{2, 3}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	expected := "/admin/customers/1/addresses/set.json?address_ids%5B%5D=2&address_ids%5B%5D=3&operation=destroy"
+	if r := last(); r.Method != "PUT" || r.Path+"?"+r.Query != expected {
+		t.Errorf("expected PUT %s, got %s %s?%s", expected, r.Method, r.Path, r.Query)
+	}
+}
+
+func TestCustomerService(t *testing.T) {
+	t.Parallel()
+
+	customer := `{"id":1,"email":"bob@example.com","first_name":"Bob","accepts_marketing":true}`
+	address := `{"id":2,"customer_id":1,"address1":"1 Main St","city":"Ottawa","default":true}`
+	inputs := []struct {
+		name     string
+		response string
+		call     func(c *Client) (string, error)
+		method   string
+		path     string
+		query    string
+		body     string
+		result   string
+	}{
+		{
+			name:     "get",
+			response: `{"customer":` + customer + `}`,
+			call: func(c *Client) (string, error) {
+				cu, _, err := c.Customer.Get(context.Background(), 1)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d %s", cu.ID, cu.Email), nil
+			},
+			method: "GET",
+			path:   "/admin/customers/1.json",
+			result: "1 bob@example.com",
+		},
+		{
+			name:     "create",
+			response: `{"customer":` + customer + `}`,
+			call: func(c *Client) (string, error) {
+				cu, _, err := c.Customer.Create(context.Background(), &Customer{
+					Email:           "bob@example.com",
+					FirstName:       "Bob",
+					SendEmailInvite: true,
+				})
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d %s", cu.ID, cu.Email), nil
+			},
+			method: "POST",
+			path:   "/admin/customers.json",
+			body:   `{"customer":{"email":"bob@example.com","first_name":"Bob","send_email_invite":true}}`,
+			result: "1 bob@example.com",
+		},
+		{
+			name:     "update",
+			response: `{"customer":` + customer + `}`,
+			call: func(c *Client) (string, error) {
+				cu, _, err := c.Customer.Update(context.Background(), &CustomerUpdate{
+					ID:               1,
+					Note:             &NullString{},
+					Tags:             String("vip"),
+					AcceptsMarketing: Bool(false),
+				})
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d %s", cu.ID, cu.Email), nil
+			},
+			method: "PUT",
+			path:   "/admin/customers/1.json",
+			body:   `{"customer":{"id":1,"note":null,"tags":"vip","accepts_marketing":false}}`,
+			result: "1 bob@example.com",
+		},
+		{
+			name:     "delete",
+			response: `{}`,
+			call: func(c *Client) (string, error) {
+				_, err := c.Customer.Delete(context.Background(), 1)
+				return "", err
+			},
+			method: "DELETE",
+			path:   "/admin/customers/1.json",
+		},
+		{
+			name:     "orders",
+			response: `{"orders":[{"id":3},{"id":4}]}`,
+			call: func(c *Client) (string, error) {
+				orders, _, err := c.Customer.Orders(context.Background(), 1, &OrderParam{Status: OrderStatusAny})
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d %d", orders[0].ID, orders[1].ID), nil
+			},
+			method: "GET",
+			path:   "/admin/customers/1/orders.json",
+			query:  "status=any",
+			result: "3 4",
+		},
+		{
+			name:     "send invite",
+			response: `{"customer_invite":{"to":"bob@example.com","from":"shop@example.com","subject":"Welcome"}}`,
+			call: func(c *Client) (string, error) {
+				invite, _, err := c.Customer.SendInvite(context.Background(), 1, &CustomerInvite{Subject: "Welcome"})
+				if err != nil {
+					return "", err
+				}
+				return invite.To + " " + invite.Subject, nil
+			},
+			method: "POST",
+			path:   "/admin/customers/1/send_invite.json",
+			body:   `{"customer_invite":{"subject":"Welcome"}}`,
+			result: "bob@example.com Welcome",
+		},
+		{
+			name:     "send default invite",
+			response: `{"customer_invite":{"to":"bob@example.com"}}`,
+			call: func(c *Client) (string, error) {
+				invite, _, err := c.Customer.SendInvite(context.Background(), 1, nil)
+				if err != nil {
+					return "", err
+				}
+				return invite.To, nil
+			},
+			method: "POST",
+			path:   "/admin/customers/1/send_invite.json",
+			body:   `{"customer_invite":{}}`,
+			result: "bob@example.com",
+		},
+		{
+			name:     "account activation url",
+			response: `{"account_activation_url":"https://x.myshopify.com/account/activate/1/abc"}`,
+			call: func(c *Client) (string, error) {
+				url, _, err := c.Customer.AccountActivationURL(context.Background(), 1)
+				return url, err
+			},
+			method: "POST",
+			path:   "/admin/customers/1/account_activation_url.json",
+			body:   `{}`,
+			result: "https://x.myshopify.com/account/activate/1/abc",
+		},
+		{
+			name:     "list addresses",
+			response: `{"addresses":[` + address + `]}`,
+			call: func(c *Client) (string, error) {
+				addresses, _, err := c.Customer.ListAddresses(context.Background(), 1)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d %s", addresses[0].ID, addresses[0].Address1), nil
+			},
+			method: "GET",
+			path:   "/admin/customers/1/addresses.json",
+			result: "2 1 Main St",
+		},
+		{
+			name:     "get address",
+			response: `{"customer_address":` + address + `}`,
+			call: func(c *Client) (string, error) {
+				a, _, err := c.Customer.GetAddress(context.Background(), 1, 2)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d %s", a.ID, a.Address1), nil
+			},
+			method: "GET",
+			path:   "/admin/customers/1/addresses/2.json",
+			result: "2 1 Main St",
+		},
+		{
+			name:     "create address",
+			response: `{"customer_address":` + address + `}`,
+			call: func(c *Client) (string, error) {
+				a, _, err := c.Customer.CreateAddress(context.Background(), 1, &CustomerAddress{
+					Address1: "1 Main St",
+					City:     "Ottawa",
+				})
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d %s", a.ID, a.Address1), nil
+			},
+			method: "POST",
+			path:   "/admin/customers/1/addresses.json",
+			result: "2 1 Main St",
+		},
+		{
+			name:     "update address",
+			response: `{"customer_address":` + address + `}`,
+			call: func(c *Client) (string, error) {
+				a, _, err := c.Customer.UpdateAddress(context.Background(), 1, &CustomerAddressUpdate{
+					ID:       2,
+					Address1: String("1 Main St"),
+					Address2: &NullString{},
+				})
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d %s", a.ID, a.Address1), nil
+			},
+			method: "PUT",
+			path:   "/admin/customers/1/addresses/2.json",
+			body:   `{"customer_address":{"id":2,"address1":"1 Main St","address2":null}}`,
+			result: "2 1 Main St",
+		},
+		{
+			name:     "set default address",
+			response: `{"customer_address":` + address + `}`,
+			call: func(c *Client) (string, error) {
+				a, _, err := c.Customer.SetDefaultAddress(context.Background(), 1, 2)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d %t", a.ID, a.Default), nil
+			},
+			method: "PUT",
+			path:   "/admin/customers/1/addresses/2/default.json",
+			result: "2 true",
+		},
+		{
+			name:     "delete address",
+			response: `{}`,
+			call: func(c *Client) (string, error) {
+				_, err := c.Customer.DeleteAddress(context.Background(), 1, 2)
+				return "", err
+			},
+			method: "DELETE",
+			path:   "/admin/customers/1/addresses/2.json",
+		},
+	}
+
+	for _, tt := range inputs {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c, last, teardown := setup(http.StatusOK, tt.response)
+			defer teardown()
+
+			result, err := tt.call(c)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			r := last()
+			if r.Method != tt.method || r.Path != tt.path || r.Query != tt.query {
+				t.Errorf("unexpected request %s %s?%s", r.Method, r.Path, r.Query)
+			}
+			if body := strings.TrimSpace(r.Body); tt.body != "" && body != tt.body {
+				t.Errorf("expected body %s got %s", tt.body, body)
+			}
+			if result != tt.result {
+				t.Errorf("expected %q got %q", tt.result, result)
+			}
+		})
+	}
+}
@@ -5,7 +5,18 @@
 
 package shopify
 
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// api reference: https://help.shopify.com/en/api/reference/customers/customer-address
+
 type CustomerAddress struct {
+	ID           int64  `json:"id,omitempty"`
+	CustomerID   int64  `json:"customer_id,omitempty"`
 	Address1     string `json:"address1"`
 	Address2     string `json:"address2,omitempty"`
 	City         string `json:"city"`
@@ -18,6 +29,117 @@ type CustomerAddress struct {
 	ProvinceCode string `json:"province_code,omitempty"`
 	Zip          string `json:"zip"`
 	CountryCode  string `json:"country_code,omitempty"`
+	Default      bool   `json:"default,omitempty"`
+}
+
+type CustomerAddressRequest struct {
+	CustomerAddress *CustomerAddress `json:"customer_address"`
+}
+
+func (s *CustomerService) ListAddresses(ctx context.Context, customerID int64) ([]*CustomerAddress, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("/admin/customers/%d/addresses.json", customerID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var addressWrapper struct {
+		Addresses []*CustomerAddress `json:"addresses"`
+	}
+	resp, err := s.client.Do(ctx, req, &addressWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return addressWrapper.Addresses, resp, nil
+}
+
+func (s *CustomerService) GetAddress(ctx context.Context, customerID, ID int64) (*CustomerAddress, *http.Response, error) {
+	return s.address(ctx, "GET", fmt.Sprintf("/admin/customers/%d/addresses/%d.json", customerID, ID), nil)
+}
+
+func (s *CustomerService) CreateAddress(ctx context.Context, customerID int64, address *CustomerAddress) (*CustomerAddress, *http.Response, error) {
+	return s.address(
+		ctx,
+		"POST",
+		fmt.Sprintf("/admin/customers/%d/addresses.json", customerID),
+		&CustomerAddressRequest{address},
+	)
+}
+
+// CustomerAddressUpdate is a partial update of a customer address. Only the
+// fields that are set are sent, see VariantUpdate.
+type CustomerAddressUpdate struct {
+	ID           int64       `json:"id"`
+	Address1     *string     `json:"address1,omitempty"`
+	Address2     *NullString `json:"address2,omitempty"`
+	City         *string     `json:"city,omitempty"`
+	Company      *NullString `json:"company,omitempty"`
+	Country      *string     `json:"country,omitempty"`
+	CountryCode  *string     `json:"country_code,omitempty"`
+	FirstName    *string     `json:"first_name,omitempty"`
+	LastName     *string     `json:"last_name,omitempty"`
+	Phone        *NullString `json:"phone,omitempty"`
+	Province     *string     `json:"province,omitempty"`
+	ProvinceCode *string     `json:"province_code,omitempty"`
+	Zip          *string     `json:"zip,omitempty"`
 }
 
-// TODO api
+// UpdateAddress updates the address with the fields that are set.
+func (s *CustomerService) UpdateAddress(ctx context.Context, customerID int64, address *CustomerAddressUpdate) (*CustomerAddress, *http.Response, error) {
+	body := struct {
+		CustomerAddress *CustomerAddressUpdate `json:"customer_address"`
+	}{address}
+	return s.address(
+		ctx,
+		"PUT",
+		fmt.Sprintf("/admin/customers/%d/addresses/%d.json", customerID, address.ID),
+		&body,
+	)
+}
+
+// SetDefaultAddress makes the address the default address of the customer.
+func (s *CustomerService) SetDefaultAddress(ctx context.Context, customerID, ID int64) (*CustomerAddress, *http.Response, error) {
+	return s.address(ctx, "PUT", fmt.Sprintf("/admin/customers/%d/addresses/%d/default.json", customerID, ID), nil)
+}
+
+func (s *CustomerService) address(ctx context.Context, method, path string, body interface{}) (*CustomerAddress, *http.Response, error) {
+	req, err := s.client.NewRequest(method, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	addressWrapper := new(CustomerAddressRequest)
+	resp, err := s.client.Do(ctx, req, addressWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return addressWrapper.CustomerAddress, resp, nil
+}
+
+// DeleteAddress deletes an address of the customer. The default address can't
+// be deleted.
+func (s *CustomerService) DeleteAddress(ctx context.Context, customerID, ID int64) (*http.Response, error) {
+	req, err := s.client.NewRequest("DELETE", fmt.Sprintf("/admin/customers/%d/addresses/%d.json", customerID, ID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
+
+// DeleteAddresses deletes several addresses of the customer at once.
+func (s *CustomerService) DeleteAddresses(ctx context.Context, customerID int64, IDs []int64) (*http.Response, error) {
+	req, err := s.client.NewRequest("PUT", fmt.Sprintf("/admin/customers/%d/addresses/set.json", customerID), nil)
+	if err != nil {
+		return nil, err
+	}
+	v := url.Values{}
+	for _, id := range IDs {
+		v.Add("address_ids[]", fmt.Sprintf("%d", id))
+	}
+	v.Add("operation", "destroy")
+	req.URL.RawQuery = v.Encode()
+
+	return s.client.Do(ctx, req, nil)
+}
@@ -11,7 +11,9 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 // Shopify errors usually have the form:
@@ -46,14 +48,50 @@ type LineItemError struct {
 	Message  string
 	Code     string
 	Position string
+	// Remaining is the quantity left in stock, for not_enough_in_stock
+	// errors
+	Remaining int
+}
+
+// LineItemErrorCode is the code of a LineItemError, see ErrorCode.
+type LineItemErrorCode string
+
+const (
+	LineItemErrorNotEnoughInStock LineItemErrorCode = "not_enough_in_stock"
+	LineItemErrorInvalid          LineItemErrorCode = "invalid"
+	LineItemErrorBlank            LineItemErrorCode = "blank"
+	// the quantity is not greater than 0
+	LineItemErrorGreaterThan LineItemErrorCode = "greater_than"
+)
+
+// ShippingLineError is an error with the shipping line of a checkout, ie.
+// an expired shipping rate. Shipping rates should be fetched again.
+type ShippingLineError struct {
+	ShopifyErrorer
+
+	Field   string
+	Code    ShippingLineErrorCode
+	Message string
+}
+
+type ShippingLineErrorCode string
+
+const (
+	// the shipping rate has expired
+	ShippingLineErrorExpired ShippingLineErrorCode = "expired"
+	// the shipping rate is not available for the checkout
+	ShippingLineErrorInvalid ShippingLineErrorCode = "invalid"
+	ShippingLineErrorBlank   ShippingLineErrorCode = "blank"
+)
+
+// CheckoutError is an error with the checkout itself, other than its
+// discount code, ie. a field of the checkout or its "base".
+type CheckoutError struct {
+	ShopifyErrorer
 
-	//Quantity []struct {
-	//Message string `json:"message"`
-	//Options struct {
-	//Remaining int `json:"remaining"`
-	//} `json:"options"`
-	//Code string `json:"code"`
-	//} `json:"quantity"`
+	Field   string
+	Code    string
+	Message string
 }
 
 type DiscountCodeError struct {
@@ -75,6 +113,16 @@ type EmailError struct {
 	Message string `json:"message"`
 }
 
+// FieldError is an error of a key of the response that has no more specific
+// type, ie. {"title": ["can't be blank"]}, so that no error Shopify returns is
+// lost.
+type FieldError struct {
+	ShopifyErrorer
+
+	Field    string
+	Messages []string
+}
+
 type ErrorResponse struct {
 	Errors interface{} `json:"errors"`
 }
@@ -92,6 +140,31 @@ func (e *LineItemError) Type() string {
 	return `line_items`
 }
 
+// ErrorCode returns the Code of the error, to compare with the
+// LineItemErrorCode constants.
+func (e *LineItemError) ErrorCode() LineItemErrorCode {
+	return LineItemErrorCode(e.Code)
+}
+
+func (e *ShippingLineError) Error() string {
+	return fmt.Sprintf("%s: %s %s", e.Type(), e.Field, e.Message)
+}
+
+func (e *ShippingLineError) Type() string {
+	return `shipping_line`
+}
+
+func (e *CheckoutError) Error() string {
+	if e.Field == "base" {
+		return fmt.Sprintf("%s: %s", e.Type(), e.Message)
+	}
+	return fmt.Sprintf("%s: %s %s", e.Type(), e.Field, e.Message)
+}
+
+func (e *CheckoutError) Type() string {
+	return `checkout`
+}
+
 func (e *DiscountCodeError) Error() string {
 	return fmt.Sprintf("%+v", e.Reason)
 }
@@ -116,11 +189,19 @@ func (e *EmailError) Type() string {
 	return `email`
 }
 
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s %s", e.Field, strings.Join(e.Messages, ", "))
+}
+
+func (e *FieldError) Type() string {
+	return e.Field
+}
+
 func (r *ErrorResponse) Error() string {
 	if e, ok := r.Errors.(map[string]interface{}); ok {
-		for k, v := range e {
+		for _, k := range sortedKeys(e) {
 			// value here can be a slice
-			return fmt.Sprintf("%s: %+v", k, v)
+			return fmt.Sprintf("%s: %+v", k, e[k])
 		}
 	}
 	if e, ok := r.Errors.(string); ok {
@@ -129,127 +210,306 @@ func (r *ErrorResponse) Error() string {
 	return "unknown, unparsed error"
 }
 
-func toAddressError(key, field string, listError []interface{}) *AddressError {
+// ValidationErrors holds the errors of a response with more than one, ie.
+// both an invalid address and a line item out of stock. The errors are sorted by field, and
+// line items by position, so the same response always gives the same errors.
+//
+// Each error can be checked with errors.As:
+//
+//	var lineItemErr *shopify.LineItemError
+//	if errors.As(err, &lineItemErr) {
+//		...
+//	}
+type ValidationErrors []error
+
+func (e ValidationErrors) Error() string {
+	s := make([]string, len(e))
+	for i, err := range e {
+		s[i] = err.Error()
+	}
+	return strings.Join(s, "; ")
+}
+
+// As finds the first error that matches target, see errors.As.
+func (e ValidationErrors) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Is reports whether any of the errors matches target, see errors.Is.
+func (e ValidationErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the keys of m in order. Numeric keys, ie. line item
+// positions, are sorted by value.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, errA := strconv.Atoi(keys[i])
+		b, errB := strconv.Atoi(keys[j])
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+func toAddressErrors(key, field string, listError []interface{}) []error {
+	var errs []error
 	for _, ee := range listError {
-		// NOTE: parse the first error found
 		if ex, _ := ee.(map[string]interface{}); ex != nil {
 			code, _ := ex["code"].(string)
 			message, _ := ex["message"].(string)
-			return &AddressError{
+			errs = append(errs, &AddressError{
 				Key:     key,
 				Field:   field,
 				Code:    code,
 				Message: message,
-			}
+			})
 		}
 	}
-	return nil
+	return errs
+}
+
+// Errors matching the status code of an *APIError with errors.Is:
+//
+//	if errors.Is(err, shopify.ErrUnauthorized) {
+//		// the app was uninstalled
+//	}
+var (
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrPaymentRequired = errors.New("payment required") // the shop is frozen
+	ErrForbidden       = errors.New("forbidden")
+	ErrNotFound        = errors.New("not found")
+	ErrLocked          = errors.New("locked") // the shop is locked
+	ErrRateLimited     = errors.New("rate limited")
+
+	statusErrors = map[int]error{
+		http.StatusUnauthorized:    ErrUnauthorized,
+		http.StatusPaymentRequired: ErrPaymentRequired,
+		http.StatusForbidden:       ErrForbidden,
+		http.StatusNotFound:        ErrNotFound,
+		http.StatusLocked:          ErrLocked,
+		http.StatusTooManyRequests: ErrRateLimited,
+	}
+)
+
+// APIError is the error returned for a response outside the 200 range.
+type APIError struct {
+	StatusCode int
+	// RequestID is the X-Request-Id of the response, to give to Shopify
+	// support
+	RequestID string
+	Method    string
+	URL       string
+	Body      []byte
+
+	// Err holds the errors parsed from the body, if any. Either a single
+	// error, ie. a *LineItemError, or ValidationErrors.
+	Err error
+}
+
+func (e *APIError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s %s: %d %s", e.Method, e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// Unwrap returns the errors parsed from the body.
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is the sentinel error of the status code, ie.
+// ErrNotFound for a 404.
+func (e *APIError) Is(target error) bool {
+	err, ok := statusErrors[e.StatusCode]
+	return ok && err == target
 }
 
 // CheckResponse checks the API response for errors, and returns them if
 // present. A response is considered an error if it has a status code outside
-// the 200 range or equal to 202 Accepted.
+// the 200 range, and is returned as an *APIError.
 // API error responses are expected to have either no response
 // body, or a JSON response body that maps to ErrorResponse. Any other
-// response body will be silently ignored.
+// response body is kept in the APIError as is.
 func CheckResponse(r *http.Response) error {
-	if r.StatusCode == http.StatusAccepted {
+	if c := r.StatusCode; 200 <= c && c <= 299 {
 		return nil
 	}
-	if r.StatusCode == http.StatusForbidden {
-		return errors.New("forbidden")
+
+	apiErr := &APIError{
+		StatusCode: r.StatusCode,
+		RequestID:  r.Header.Get("X-Request-Id"),
 	}
-	if c := r.StatusCode; 200 <= c && c <= 299 {
-		return nil
+	if r.Request != nil {
+		apiErr.Method = r.Request.Method
+		apiErr.URL = r.Request.URL.String()
 	}
-	errorResponse := &ErrorResponse{}
 	data, err := ioutil.ReadAll(r.Body)
-	if err == nil && data != nil {
-		json.Unmarshal(data, errorResponse)
+	if err == nil && len(data) > 0 {
+		apiErr.Body = data
+		errorResponse := &ErrorResponse{}
+		if json.Unmarshal(data, errorResponse) == nil && errorResponse.Errors != nil {
+			apiErr.Err = findErrors(errorResponse)
+		}
 	}
-	return findFirstError(errorResponse)
+	return apiErr
 }
 
-func findFirstError(r *ErrorResponse) error {
+// findErrors returns the ValidationErrors of the response, or r itself if
+// none could be parsed. A single error is returned as is, ie. as a
+// *LineItemError.
+func findErrors(r *ErrorResponse) error {
 	rr, ok := r.Errors.(map[string]interface{})
 	if !ok {
 		return r
 	}
 
-	// find the first error, and return
-	for k, v := range rr {
-		if k == "email" {
-			return &EmailError{
-				Message: "is invalid",
-			}
-		}
-		if vv, ok := v.(map[string]interface{}); ok {
-			switch k {
-			//TODO: shipping_line: map[id:[map[code:expired message:has expired options:map[]]]]
-			case "line_items":
-				for pos, vvv := range vv {
-					b, _ := json.Marshal(vvv)
-
-					var e lineItemErrorField
-					json.Unmarshal(b, &e)
-
-					ee := &LineItemError{
-						Position: pos,
-					}
-					if e != nil {
-						for ek, ev := range e {
-							ee.Field = ek
-							ee.Message = ev[0].Message
-							ee.Code = ev[0].Code
-							break
-						}
-					}
+	var errs ValidationErrors
+	for _, k := range sortedKeys(rr) {
+		errs = append(errs, toErrors(k, rr[k])...)
+	}
+	switch len(errs) {
+	case 0:
+		return r
+	case 1:
+		return errs[0]
+	}
+	return errs
+}
+
+// toErrors parses the errors of a top level key of the response.
+func toErrors(k string, v interface{}) []error {
+	if k == "email" {
+		return []error{&EmailError{Message: "is invalid"}}
+	}
+
+	var errs []error
+	if vv, ok := v.(map[string]interface{}); ok {
+		switch k {
+		case "line_items":
+			for _, pos := range sortedKeys(vv) {
+				b, _ := json.Marshal(vv[pos])
 
-					return ee
+				var e lineItemErrorField
+				json.Unmarshal(b, &e)
+
+				fields := make([]string, 0, len(e))
+				for ek := range e {
+					fields = append(fields, ek)
+				}
+				sort.Strings(fields)
+				for _, ek := range fields {
+					for _, ev := range e[ek] {
+						errs = append(errs, &LineItemError{
+							Position:  pos,
+							Field:     ek,
+							Message:   ev.Message,
+							Code:      ev.Code,
+							Remaining: ev.Options.Remaining,
+						})
+					}
 				}
+			}
 
-			case "checkout":
-				for kk, vvv := range vv {
-					switch kk {
-					case "discount_code":
-						// list of fields
-						if e, _ := vvv.([]interface{}); e != nil {
-							for _, ee := range e {
-								if ex, _ := ee.(map[string]interface{}); ex != nil {
-									for _, reason := range ex {
-										return &DiscountCodeError{Reason: reason}
-									}
-								}
-							}
-						}
+		case "checkout":
+			for _, kk := range sortedKeys(vv) {
+				e, _ := vv[kk].([]interface{})
+				for _, ee := range e {
+					ex, _ := ee.(map[string]interface{})
+					if ex == nil {
+						continue
+					}
+					code, _ := ex["code"].(string)
+					message, _ := ex["message"].(string)
+					if kk == "discount_code" {
+						errs = append(errs, &DiscountCodeError{Reason: ex["message"]})
+						continue
 					}
+					errs = append(errs, &CheckoutError{Field: kk, Code: code, Message: message})
 				}
-			case "shipping_address", "billing_address":
-				for kk, vvv := range vv {
-					if e, ok := vvv.([]interface{}); ok && e != nil {
-						return toAddressError(k, kk, e)
+			}
+		case "shipping_line":
+			for _, kk := range sortedKeys(vv) {
+				e, _ := vv[kk].([]interface{})
+				for _, ee := range e {
+					if ex, _ := ee.(map[string]interface{}); ex != nil {
+						code, _ := ex["code"].(string)
+						message, _ := ex["message"].(string)
+						errs = append(errs, &ShippingLineError{
+							Field:   kk,
+							Code:    ShippingLineErrorCode(code),
+							Message: message,
+						})
 					}
 				}
 			}
-		} else if vv, ok := v.([]interface{}); ok {
-			switch k {
-			case "discount_code":
-				for _, vvv := range vv {
-					vvvv := vvv.(map[string]interface{})
-					return &DiscountCodeError{
+		case "shipping_address", "billing_address":
+			for _, kk := range sortedKeys(vv) {
+				if e, ok := vv[kk].([]interface{}); ok && e != nil {
+					errs = append(errs, toAddressErrors(k, kk, e)...)
+				}
+			}
+		}
+	} else if vv, ok := v.([]interface{}); ok {
+		switch k {
+		case "discount_code":
+			for _, vvv := range vv {
+				if vvvv, _ := vvv.(map[string]interface{}); vvvv != nil {
+					errs = append(errs, &DiscountCodeError{
 						Reason: vvvv["message"],
-					}
+					})
 				}
-
 			}
-		} else {
-			// TODO: concrete error type..
-			// I'm not keen on using another package like errorx to wrap error
-			// cause here. maybe need to expand the error handling a lot more
-			// for things to make sense.
-			return fmt.Errorf("unknown shopify error key %s, %+v, %v", k, v, reflect.TypeOf(v))
 		}
 	}
+	if len(errs) == 0 {
+		// keys that aren't recognized, or that couldn't be parsed, are kept
+		// as is
+		errs = append(errs, &FieldError{Field: k, Messages: errorMessages(v)})
+	}
+	return errs
+}
 
-	return r
+// errorMessages flattens the errors of a key of the response into their
+// messages.
+func errorMessages(v interface{}) []string {
+	switch vv := v.(type) {
+	case string:
+		return []string{vv}
+	case []interface{}:
+		var messages []string
+		for _, e := range vv {
+			messages = append(messages, errorMessages(e)...)
+		}
+		return messages
+	case map[string]interface{}:
+		if message, ok := vv["message"].(string); ok {
+			return []string{message}
+		}
+		var messages []string
+		for _, k := range sortedKeys(vv) {
+			for _, m := range errorMessages(vv[k]) {
+				messages = append(messages, k+" "+m)
+			}
+		}
+		return messages
+	}
+	return []string{fmt.Sprint(v)}
 }
@@ -7,6 +7,9 @@ package shopify
 
 import (
 	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
 	"strings"
 	"testing"
 )
@@ -112,15 +115,252 @@ func TestShopifyError(t *testing.T) {
 } `,
 			expected: "Unable to find a valid discount matching the code entered",
 		},
+		{
+			name: "expired shipping rate",
+			input: `
+{
+  "errors": {
+    "shipping_line": {
+      "id": [
+        {
+          "code": "expired",
+          "message": "has expired",
+          "options": {}
+        }
+      ]
+    }
+  }
+}`,
+			expected: "shipping_line: id has expired",
+		},
+		{
+			name: "checkout base error",
+			input: `
+{
+  "errors": {
+    "checkout": {
+      "base": [
+        {
+          "code": "locked",
+          "message": "Checkout is already completed.",
+          "options": {}
+        }
+      ]
+    }
+  }
+}`,
+			expected: "checkout: Checkout is already completed.",
+		},
+		{
+			name:     "unknown key",
+			input:    `{"errors": {"title": ["can't be blank", "is too long"]}}`,
+			expected: "title can't be blank, is too long",
+		},
 	}
 
 	for _, tt := range inputs {
 		r := &ErrorResponse{}
 		json.Unmarshal([]byte(tt.input), &r)
-		actual := findFirstError(r)
+		actual := findErrors(r)
+		if _, ok := actual.(ValidationErrors); ok {
+			t.Errorf("%s: expected a single error, got %v", tt.name, actual)
+		}
 		if !strings.Contains(actual.Error(), tt.expected) {
 			t.Errorf("%s: expected %s got %s", tt.name, tt.expected, actual)
 		}
 	}
 
 }
+
+func TestValidationErrors(t *testing.T) {
+	t.Parallel()
+
+	input := `
+{
+  "errors": {
+    "shipping_address": {
+      "zip": [{"code": "blank", "message": "can't be blank", "options": {}}],
+      "country": [{"code": "not_supported", "message": "is not supported", "options": {}}]
+    },
+    "line_items": {
+      "10": {"quantity": [{"code": "not_enough_in_stock", "message": "not enough in stock", "options": {"remaining": 1}}]},
+      "2": {"variant_id": [{"code": "invalid", "message": "is invalid", "options": {}}]}
+    },
+    "discount_code": [
+      {"code": "discount_not_found", "message": "Unable to find a valid discount matching the code entered", "options": {}}
+    ],
+    "email": [{"code": "invalid", "message": "is invalid", "options": {}}],
+    "gift_cards": [{"code": "invalid", "message": "is invalid", "options": {}}]
+  }
+}`
+	expected := []string{
+		"Unable to find a valid discount matching the code entered",
+		"email is invalid",
+		"gift_cards is invalid",
+		"line_items at pos(2): variant_id is invalid",
+		"line_items at pos(10): quantity not enough in stock",
+		"shipping_address: country is not supported",
+		"shipping_address: zip can't be blank",
+	}
+
+	// maps are walked in random order, the errors must not be
+	for i := 0; i < 10; i++ {
+		r := &ErrorResponse{}
+		json.Unmarshal([]byte(input), &r)
+		err := findErrors(r)
+
+		errs, ok := err.(ValidationErrors)
+		if !ok || len(errs) != len(expected) {
+			t.Fatalf("expected %d validation errors, got %v", len(expected), err)
+		}
+		for j, e := range errs {
+			if e.Error() != expected[j] {
+				t.Errorf("error %d: expected %q got %q", j, expected[j], e.Error())
+			}
+		}
+	}
+
+	r := &ErrorResponse{}
+	json.Unmarshal([]byte(input), &r)
+	err := findErrors(r)
+	var (
+		lineItemErr *LineItemError
+		addressErr  *AddressError
+		discountErr *DiscountCodeError
+		emailErr    *EmailError
+		fieldErr    *FieldError
+	)
+	if !errors.As(err, &lineItemErr) || lineItemErr.Position != "2" {
+		t.Errorf("expected errors.As to find the first line item error, got %+v", lineItemErr)
+	}
+	if !errors.As(err, &addressErr) || addressErr.Field != "country" {
+		t.Errorf("expected errors.As to find the first address error, got %+v", addressErr)
+	}
+	if !errors.As(err, &discountErr) || !errors.As(err, &emailErr) {
+		t.Errorf("expected errors.As to find the discount code and email errors")
+	}
+	// unknown keys are kept along with the known ones
+	if !errors.As(err, &fieldErr) || fieldErr.Field != "gift_cards" {
+		t.Errorf("expected errors.As to find the gift cards error, got %+v", fieldErr)
+	}
+}
+
+func TestCheckResponse(t *testing.T) {
+	t.Parallel()
+
+	inputs := []struct {
+		name     string
+		status   int
+		body     string
+		expected error
+		message  string
+	}{
+		{"ok", http.StatusOK, `{}`, nil, ""},
+		{"accepted", http.StatusAccepted, ``, nil, ""},
+		{"unauthorized", http.StatusUnauthorized, `{"errors":"[API] Invalid API key or access token"}`, ErrUnauthorized, "[API] Invalid API key or access token"},
+		{"frozen", http.StatusPaymentRequired, ``, ErrPaymentRequired, "GET https://x.myshopify.com/admin/shop.json: 402 Payment Required"},
+		{"forbidden", http.StatusForbidden, `{"errors":"Forbidden"}`, ErrForbidden, "Forbidden"},
+		{"not found", http.StatusNotFound, `{"errors":"Not Found"}`, ErrNotFound, "Not Found"},
+		{"locked", http.StatusLocked, `<html></html>`, ErrLocked, "GET https://x.myshopify.com/admin/shop.json: 423 Locked"},
+		{"rate limited", http.StatusTooManyRequests, `{"errors":"Exceeded 2 calls per second for api client."}`, ErrRateLimited, "Exceeded 2 calls per second for api client."},
+		{"bad request", http.StatusUnprocessableEntity, `{"errors":{"email":[{"code":"invalid","message":"is invalid"}]}}`, nil, "email is invalid"},
+	}
+
+	for _, tt := range inputs {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, _ := http.NewRequest("GET", "https://x.myshopify.com/admin/shop.json", nil)
+			resp := &http.Response{
+				StatusCode: tt.status,
+				Header:     http.Header{"X-Request-Id": {"abc-123"}},
+				Body:       ioutil.NopCloser(strings.NewReader(tt.body)),
+				Request:    req,
+			}
+			err := CheckResponse(resp)
+			if tt.message == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected an *APIError, got %v", err)
+			}
+			if apiErr.StatusCode != tt.status || apiErr.RequestID != "abc-123" || string(apiErr.Body) != tt.body {
+				t.Errorf("unexpected api error %+v", apiErr)
+			}
+			if err.Error() != tt.message {
+				t.Errorf("expected message %q got %q", tt.message, err.Error())
+			}
+			for _, sentinel := range []error{ErrUnauthorized, ErrPaymentRequired, ErrForbidden, ErrNotFound, ErrLocked, ErrRateLimited} {
+				if errors.Is(err, sentinel) != (sentinel == tt.expected) {
+					t.Errorf("unexpected errors.Is(%v) = %v", sentinel, !(sentinel == tt.expected))
+				}
+			}
+		})
+	}
+
+	// validation errors are still reachable through the api error
+	req, _ := http.NewRequest("POST", "https://x.myshopify.com/admin/customers.json", nil)
+	err := CheckResponse(&http.Response{
+		StatusCode: http.StatusUnprocessableEntity,
+		Body:       ioutil.NopCloser(strings.NewReader(`{"errors":{"email":[{"code":"invalid","message":"is invalid"}]}}`)),
+		Request:    req,
+	})
+	var emailErr *EmailError
+	if !errors.As(err, &emailErr) {
+		t.Errorf("expected errors.As to find the email error in %v", err)
+	}
+}
+
+func TestTypedErrors(t *testing.T) {
+	t.Parallel()
+
+	input := `
+{
+  "errors": {
+    "line_items": {
+      "0": {
+        "quantity": [
+          {
+            "code": "not_enough_in_stock",
+            "message": "Not enough items available. Only 2 left.",
+            "options": {"remaining": 2}
+          }
+        ]
+      }
+    },
+    "shipping_line": {
+      "id": [{"code": "expired", "message": "has expired", "options": {}}]
+    },
+    "checkout": {
+      "email": [{"code": "invalid", "message": "is invalid", "options": {}}]
+    }
+  }
+}`
+	r := &ErrorResponse{}
+	json.Unmarshal([]byte(input), &r)
+	err := findErrors(r)
+
+	var lineItemErr *LineItemError
+	if !errors.As(err, &lineItemErr) {
+		t.Fatalf("expected a line item error in %v", err)
+	}
+	if lineItemErr.ErrorCode() != LineItemErrorNotEnoughInStock || lineItemErr.Code != ErrNotEnoughInStock || lineItemErr.Remaining != 2 {
+		t.Errorf("expected not_enough_in_stock with 2 remaining, got %+v", lineItemErr)
+	}
+
+	var shippingErr *ShippingLineError
+	if !errors.As(err, &shippingErr) || shippingErr.Code != ShippingLineErrorExpired {
+		t.Errorf("expected an expired shipping line error, got %+v", shippingErr)
+	}
+
+	var checkoutErr *CheckoutError
+	if !errors.As(err, &checkoutErr) || checkoutErr.Field != "email" || checkoutErr.Code != "invalid" {
+		t.Errorf("expected a checkout email error, got %+v", checkoutErr)
+	}
+}
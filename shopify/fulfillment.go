@@ -0,0 +1,236 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// api reference: https://help.shopify.com/en/api/reference/shipping-and-fulfillment/fulfillment
+
+type FulfillmentService service
+
+type Fulfillment struct {
+	ID             int64             `json:"id,omitempty"`
+	OrderID        int64             `json:"order_id,omitempty"`
+	LocationID     int64             `json:"location_id,omitempty"`
+	Name           string            `json:"name,omitempty"`
+	Status         FulfillmentStatus `json:"status,omitempty"`
+	ShipmentStatus string            `json:"shipment_status,omitempty"`
+	Service        string            `json:"service,omitempty"`
+
+	TrackingCompany string   `json:"tracking_company,omitempty"`
+	TrackingNumber  string   `json:"tracking_number,omitempty"`
+	TrackingNumbers []string `json:"tracking_numbers,omitempty"`
+	TrackingURL     string   `json:"tracking_url,omitempty"`
+	TrackingURLs    []string `json:"tracking_urls,omitempty"`
+
+	LineItems []*OrderLineItem `json:"line_items,omitempty"`
+
+	// Create only
+	NotifyCustomer              bool                         `json:"notify_customer,omitempty"`
+	TrackingInfo                *FulfillmentTrackingInfo     `json:"tracking_info,omitempty"`
+	LineItemsByFulfillmentOrder []*FulfillmentOrderLineItems `json:"line_items_by_fulfillment_order,omitempty"`
+
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+type FulfillmentStatus string
+
+const (
+	FulfillmentStatusPending   FulfillmentStatus = "pending"
+	FulfillmentStatusOpen      FulfillmentStatus = "open"
+	FulfillmentStatusSuccess   FulfillmentStatus = "success"
+	FulfillmentStatusCancelled FulfillmentStatus = "cancelled"
+	FulfillmentStatusError     FulfillmentStatus = "error"
+	FulfillmentStatusFailure   FulfillmentStatus = "failure"
+)
+
+type FulfillmentTrackingInfo struct {
+	Number  string `json:"number,omitempty"`
+	URL     string `json:"url,omitempty"`
+	Company string `json:"company,omitempty"`
+}
+
+// FulfillmentOrderLineItems are the line items of a fulfillment order to
+// fulfill. Leave FulfillmentOrderLineItems empty to fulfill all of them.
+type FulfillmentOrderLineItems struct {
+	FulfillmentOrderID        int64                       `json:"fulfillment_order_id"`
+	FulfillmentOrderLineItems []*FulfillmentOrderLineItem `json:"fulfillment_order_line_items,omitempty"`
+}
+
+type FulfillmentEvent struct {
+	ID            int64                  `json:"id,omitempty"`
+	FulfillmentID int64                  `json:"fulfillment_id,omitempty"`
+	OrderID       int64                  `json:"order_id,omitempty"`
+	Status        FulfillmentEventStatus `json:"status,omitempty"`
+	Message       string                 `json:"message,omitempty"`
+
+	Address1  string  `json:"address1,omitempty"`
+	City      string  `json:"city,omitempty"`
+	Province  string  `json:"province,omitempty"`
+	Zip       string  `json:"zip,omitempty"`
+	Country   string  `json:"country,omitempty"`
+	Latitude  float64 `json:"latitude,omitempty"`
+	Longitude float64 `json:"longitude,omitempty"`
+
+	EstimatedDeliveryAt *time.Time `json:"estimated_delivery_at,omitempty"`
+	HappenedAt          *time.Time `json:"happened_at,omitempty"`
+	CreatedAt           *time.Time `json:"created_at,omitempty"`
+	UpdatedAt           *time.Time `json:"updated_at,omitempty"`
+}
+
+type FulfillmentEventStatus string
+
+const (
+	FulfillmentEventStatusLabelPrinted      FulfillmentEventStatus = "label_printed"
+	FulfillmentEventStatusLabelPurchased    FulfillmentEventStatus = "label_purchased"
+	FulfillmentEventStatusAttemptedDelivery FulfillmentEventStatus = "attempted_delivery"
+	FulfillmentEventStatusReadyForPickup    FulfillmentEventStatus = "ready_for_pickup"
+	FulfillmentEventStatusConfirmed         FulfillmentEventStatus = "confirmed"
+	FulfillmentEventStatusInTransit         FulfillmentEventStatus = "in_transit"
+	FulfillmentEventStatusOutForDelivery    FulfillmentEventStatus = "out_for_delivery"
+	FulfillmentEventStatusDelivered         FulfillmentEventStatus = "delivered"
+	FulfillmentEventStatusFailure           FulfillmentEventStatus = "failure"
+)
+
+type FulfillmentRequest struct {
+	Fulfillment *Fulfillment `json:"fulfillment"`
+}
+
+type FulfillmentEventRequest struct {
+	FulfillmentEvent *FulfillmentEvent `json:"event"`
+}
+
+func (s *FulfillmentService) List(ctx context.Context, orderID int64) ([]*Fulfillment, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("/admin/orders/%d/fulfillments.json", orderID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var fulfillmentWrapper struct {
+		Fulfillments []*Fulfillment `json:"fulfillments"`
+	}
+	resp, err := s.client.Do(ctx, req, &fulfillmentWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return fulfillmentWrapper.Fulfillments, resp, nil
+}
+
+func (s *FulfillmentService) Get(ctx context.Context, orderID, ID int64) (*Fulfillment, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("/admin/orders/%d/fulfillments/%d.json", orderID, ID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fulfillmentWrapper := new(FulfillmentRequest)
+	resp, err := s.client.Do(ctx, req, fulfillmentWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return fulfillmentWrapper.Fulfillment, resp, nil
+}
+
+// Create fulfills the line items of one or more fulfillment orders:
+//
+//	client.Fulfillment.Create(ctx, &shopify.Fulfillment{
+//		NotifyCustomer: true,
+//		TrackingInfo:   &shopify.FulfillmentTrackingInfo{Number: "1Z2345", Company: "UPS"},
+//		LineItemsByFulfillmentOrder: []*shopify.FulfillmentOrderLineItems{
+//			{FulfillmentOrderID: fulfillmentOrderID},
+//		},
+//	})
+func (s *FulfillmentService) Create(ctx context.Context, fulfillment *Fulfillment) (*Fulfillment, *http.Response, error) {
+	return s.post(ctx, "/admin/fulfillments.json", &FulfillmentRequest{fulfillment})
+}
+
+// UpdateTracking replaces the tracking info of the fulfillment.
+func (s *FulfillmentService) UpdateTracking(ctx context.Context, ID int64, info *FulfillmentTrackingInfo, notifyCustomer bool) (*Fulfillment, *http.Response, error) {
+	return s.post(
+		ctx,
+		fmt.Sprintf("/admin/fulfillments/%d/update_tracking.json", ID),
+		&FulfillmentRequest{&Fulfillment{TrackingInfo: info, NotifyCustomer: notifyCustomer}},
+	)
+}
+
+func (s *FulfillmentService) Cancel(ctx context.Context, ID int64) (*Fulfillment, *http.Response, error) {
+	return s.post(ctx, fmt.Sprintf("/admin/fulfillments/%d/cancel.json", ID), struct{}{})
+}
+
+func (s *FulfillmentService) post(ctx context.Context, path string, body interface{}) (*Fulfillment, *http.Response, error) {
+	req, err := s.client.NewRequest("POST", path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fulfillmentWrapper := new(FulfillmentRequest)
+	resp, err := s.client.Do(ctx, req, fulfillmentWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return fulfillmentWrapper.Fulfillment, resp, nil
+}
+
+func (s *FulfillmentService) ListEvents(ctx context.Context, orderID, fulfillmentID int64) ([]*FulfillmentEvent, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("/admin/orders/%d/fulfillments/%d/events.json", orderID, fulfillmentID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var eventWrapper struct {
+		FulfillmentEvents []*FulfillmentEvent `json:"fulfillment_events"`
+	}
+	resp, err := s.client.Do(ctx, req, &eventWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return eventWrapper.FulfillmentEvents, resp, nil
+}
+
+// CreateEvent records a shipping update of the fulfillment, ie. that it is
+// out for delivery.
+func (s *FulfillmentService) CreateEvent(ctx context.Context, orderID, fulfillmentID int64, event *FulfillmentEvent) (*FulfillmentEvent, *http.Response, error) {
+	req, err := s.client.NewRequest(
+		"POST",
+		fmt.Sprintf("/admin/orders/%d/fulfillments/%d/events.json", orderID, fulfillmentID),
+		&FulfillmentEventRequest{event},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var eventWrapper struct {
+		FulfillmentEvent *FulfillmentEvent `json:"fulfillment_event"`
+	}
+	resp, err := s.client.Do(ctx, req, &eventWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return eventWrapper.FulfillmentEvent, resp, nil
+}
+
+func (s *FulfillmentService) DeleteEvent(ctx context.Context, orderID, fulfillmentID, ID int64) (*http.Response, error) {
+	req, err := s.client.NewRequest(
+		"DELETE",
+		fmt.Sprintf("/admin/orders/%d/fulfillments/%d/events/%d.json", orderID, fulfillmentID, ID),
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
@@ -0,0 +1,158 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestFulfillmentCreate(t *testing.T) {
+	t.Parallel()
+
+	c, last, teardown := setup(http.StatusCreated, `{"fulfillment":{"id":1,"order_id":2,"status":"success","tracking_number":"1Z2345"}}`)
+	defer teardown()
+
+	fulfillment, _, err := c.Fulfillment.Create(context.Background(), &Fulfillment{
+		NotifyCustomer: true,
+		TrackingInfo:   &FulfillmentTrackingInfo{Number: "1Z2345", Company: "UPS"},
+		LineItemsByFulfillmentOrder: []*FulfillmentOrderLineItems{
+			{FulfillmentOrderID: 3, FulfillmentOrderLineItems: []*FulfillmentOrderLineItem{{ID: 4, Quantity: 1}}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	r := last()
+	if r.Path != "/admin/fulfillments.json" {
+		t.Errorf("unexpected path %s", r.Path)
+	}
+	expected := `{"fulfillment":{"notify_customer":true,"tracking_info":{"number":"1Z2345","company":"UPS"},` +
+		`"line_items_by_fulfillment_order":[{"fulfillment_order_id":3,"fulfillment_order_line_items":[{"id":4,"quantity":1}]}]}}`
+	if body := strings.TrimSpace(r.Body); body != expected {
+		t.Errorf("expected body %s\ngot %s", expected, body)
+	}
+	if fulfillment.Status != FulfillmentStatusSuccess || fulfillment.TrackingNumber != "1Z2345" {
+		t.Errorf("unexpected fulfillment %+v", fulfillment)
+	}
+}
+
+func TestFulfillmentService(t *testing.T) {
+	t.Parallel()
+
+	fulfillment := `{"fulfillment":{"id":2,"order_id":1,"status":"success","tracking_number":"1Z9999"}}`
+	event := `{"id":3,"fulfillment_id":2,"order_id":1,"status":"in_transit"}`
+	inputs := []struct {
+		name     string
+		response string
+		call     func(c *Client) (string, error)
+		method   string
+		path     string
+		body     string
+		result   string
+	}{
+		{
+			name:     "update tracking",
+			response: fulfillment,
+			call: func(c *Client) (string, error) {
+				f, _, err := c.Fulfillment.UpdateTracking(context.Background(), 2, &FulfillmentTrackingInfo{Number: "1Z9999", Company: "UPS"}, true)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d %s", f.ID, f.TrackingNumber), nil
+			},
+			method: "POST",
+			path:   "/admin/fulfillments/2/update_tracking.json",
+			body:   `{"fulfillment":{"notify_customer":true,"tracking_info":{"number":"1Z9999","company":"UPS"}}}`,
+			result: "2 1Z9999",
+		},
+		{
+			name:     "cancel",
+			response: `{"fulfillment":{"id":2,"order_id":1,"status":"cancelled"}}`,
+			call: func(c *Client) (string, error) {
+				f, _, err := c.Fulfillment.Cancel(context.Background(), 2)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d %s", f.ID, f.Status), nil
+			},
+			method: "POST",
+			path:   "/admin/fulfillments/2/cancel.json",
+			body:   `{}`,
+			result: "2 cancelled",
+		},
+		{
+			name:     "list events",
+			response: `{"fulfillment_events":[` + event + `]}`,
+			call: func(c *Client) (string, error) {
+				events, _, err := c.Fulfillment.ListEvents(context.Background(), 1, 2)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d %d %s", len(events), events[0].ID, events[0].Status), nil
+			},
+			method: "GET",
+			path:   "/admin/orders/1/fulfillments/2/events.json",
+			result: "1 3 in_transit",
+		},
+		{
+			name:     "create event",
+			response: `{"fulfillment_event":` + event + `}`,
+			call: func(c *Client) (string, error) {
+				e, _, err := c.Fulfillment.CreateEvent(context.Background(), 1, 2, &FulfillmentEvent{
+					Status: FulfillmentEventStatusInTransit,
+					City:   "Ottawa",
+				})
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d %s", e.ID, e.Status), nil
+			},
+			method: "POST",
+			path:   "/admin/orders/1/fulfillments/2/events.json",
+			body:   `{"event":{"status":"in_transit","city":"Ottawa"}}`,
+			result: "3 in_transit",
+		},
+		{
+			name:     "delete event",
+			response: `{}`,
+			call: func(c *Client) (string, error) {
+				_, err := c.Fulfillment.DeleteEvent(context.Background(), 1, 2, 3)
+				return "", err
+			},
+			method: "DELETE",
+			path:   "/admin/orders/1/fulfillments/2/events/3.json",
+		},
+	}
+
+	for _, tt := range inputs {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c, last, teardown := setup(http.StatusOK, tt.response)
+			defer teardown()
+
+			result, err := tt.call(c)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			r := last()
+			if r.Method != tt.method || r.Path != tt.path {
+				t.Errorf("unexpected request %s %s", r.Method, r.Path)
+			}
+			if body := strings.TrimSpace(r.Body); body != tt.body {
+				t.Errorf("expected body %s got %s", tt.body, body)
+			}
+			if result != tt.result {
+				t.Errorf("expected %q got %q", tt.result, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// api reference: https://help.shopify.com/en/api/reference/shipping-and-fulfillment/fulfillmentorder
+
+type FulfillmentOrderService service
+
+// FulfillmentOrder is the group of line items of an order to be fulfilled
+// from the same location.
+type FulfillmentOrder struct {
+	ID                 int64                  `json:"id"`
+	ShopID             int64                  `json:"shop_id"`
+	OrderID            int64                  `json:"order_id"`
+	AssignedLocationID int64                  `json:"assigned_location_id"`
+	Status             FulfillmentOrderStatus `json:"status"`
+	RequestStatus      string                 `json:"request_status"`
+	SupportedActions   []string               `json:"supported_actions"`
+
+	AssignedLocation *FulfillmentOrderLocation   `json:"assigned_location"`
+	Destination      *CustomerAddress            `json:"destination"`
+	LineItems        []*FulfillmentOrderLineItem `json:"line_items"`
+
+	FulfillAt *time.Time `json:"fulfill_at"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+type FulfillmentOrderStatus string
+
+const (
+	FulfillmentOrderStatusOpen       FulfillmentOrderStatus = "open"
+	FulfillmentOrderStatusInProgress FulfillmentOrderStatus = "in_progress"
+	FulfillmentOrderStatusScheduled  FulfillmentOrderStatus = "scheduled"
+	FulfillmentOrderStatusOnHold     FulfillmentOrderStatus = "on_hold"
+	FulfillmentOrderStatusIncomplete FulfillmentOrderStatus = "incomplete"
+	FulfillmentOrderStatusCancelled  FulfillmentOrderStatus = "cancelled"
+	FulfillmentOrderStatusClosed     FulfillmentOrderStatus = "closed"
+)
+
+type FulfillmentOrderLocation struct {
+	LocationID  int64  `json:"location_id"`
+	Name        string `json:"name"`
+	Address1    string `json:"address1"`
+	Address2    string `json:"address2"`
+	City        string `json:"city"`
+	Province    string `json:"province"`
+	Zip         string `json:"zip"`
+	CountryCode string `json:"country_code"`
+	Phone       string `json:"phone"`
+}
+
+// FulfillmentOrderLineItem is a line item of a fulfillment order. Only ID and
+// Quantity are sent when fulfilling or moving line items.
+type FulfillmentOrderLineItem struct {
+	ID                  int64 `json:"id"`
+	ShopID              int64 `json:"shop_id,omitempty"`
+	FulfillmentOrderID  int64 `json:"fulfillment_order_id,omitempty"`
+	LineItemID          int64 `json:"line_item_id,omitempty"`
+	InventoryItemID     int64 `json:"inventory_item_id,omitempty"`
+	VariantID           int64 `json:"variant_id,omitempty"`
+	Quantity            int   `json:"quantity,omitempty"`
+	FulfillableQuantity int   `json:"fulfillable_quantity,omitempty"`
+}
+
+// FulfillmentHold is the reason a fulfillment order is put on hold.
+type FulfillmentHold struct {
+	Reason         FulfillmentHoldReason `json:"reason"`
+	ReasonNotes    string                `json:"reason_notes,omitempty"`
+	NotifyMerchant bool                  `json:"notify_merchant,omitempty"`
+}
+
+type FulfillmentHoldReason string
+
+const (
+	FulfillmentHoldReasonAwaitingPayment     FulfillmentHoldReason = "awaiting_payment"
+	FulfillmentHoldReasonHighRiskOfFraud     FulfillmentHoldReason = "high_risk_of_fraud"
+	FulfillmentHoldReasonIncorrectAddress    FulfillmentHoldReason = "incorrect_address"
+	FulfillmentHoldReasonInventoryOutOfStock FulfillmentHoldReason = "inventory_out_of_stock"
+	FulfillmentHoldReasonOther               FulfillmentHoldReason = "other"
+)
+
+// FulfillmentOrderMove is the result of moving a fulfillment order to
+// another location.
+type FulfillmentOrderMove struct {
+	OriginalFulfillmentOrder  *FulfillmentOrder `json:"original_fulfillment_order"`
+	MovedFulfillmentOrder     *FulfillmentOrder `json:"moved_fulfillment_order"`
+	RemainingFulfillmentOrder *FulfillmentOrder `json:"remaining_fulfillment_order"`
+}
+
+type FulfillmentOrderRequest struct {
+	FulfillmentOrder *FulfillmentOrder `json:"fulfillment_order"`
+}
+
+// ListByOrder returns the fulfillment orders of the order.
+func (s *FulfillmentOrderService) ListByOrder(ctx context.Context, orderID int64) ([]*FulfillmentOrder, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("/admin/orders/%d/fulfillment_orders.json", orderID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var fulfillmentOrderWrapper struct {
+		FulfillmentOrders []*FulfillmentOrder `json:"fulfillment_orders"`
+	}
+	resp, err := s.client.Do(ctx, req, &fulfillmentOrderWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return fulfillmentOrderWrapper.FulfillmentOrders, resp, nil
+}
+
+func (s *FulfillmentOrderService) Get(ctx context.Context, ID int64) (*FulfillmentOrder, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("/admin/fulfillment_orders/%d.json", ID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fulfillmentOrderWrapper := new(FulfillmentOrderRequest)
+	resp, err := s.client.Do(ctx, req, fulfillmentOrderWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return fulfillmentOrderWrapper.FulfillmentOrder, resp, nil
+}
+
+// Move moves the fulfillment order to another location. Only the given line
+// items are moved, or all of them if lineItems is empty.
+func (s *FulfillmentOrderService) Move(ctx context.Context, ID, newLocationID int64, lineItems []*FulfillmentOrderLineItem) (*FulfillmentOrderMove, *http.Response, error) {
+	type move struct {
+		NewLocationID int64                       `json:"new_location_id"`
+		LineItems     []*FulfillmentOrderLineItem `json:"fulfillment_order_line_items,omitempty"`
+	}
+	body := struct {
+		FulfillmentOrder *move `json:"fulfillment_order"`
+	}{&move{newLocationID, lineItems}}
+
+	req, err := s.client.NewRequest("POST", fmt.Sprintf("/admin/fulfillment_orders/%d/move.json", ID), &body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	moveWrapper := new(FulfillmentOrderMove)
+	resp, err := s.client.Do(ctx, req, moveWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return moveWrapper, resp, nil
+}
+
+// Hold stops the fulfillment order from being fulfilled until released.
+func (s *FulfillmentOrderService) Hold(ctx context.Context, ID int64, hold *FulfillmentHold) (*FulfillmentOrder, *http.Response, error) {
+	return s.post(ctx, fmt.Sprintf("/admin/fulfillment_orders/%d/hold.json", ID), struct {
+		FulfillmentHold *FulfillmentHold `json:"fulfillment_hold"`
+	}{hold})
+}
+
+func (s *FulfillmentOrderService) ReleaseHold(ctx context.Context, ID int64) (*FulfillmentOrder, *http.Response, error) {
+	return s.post(ctx, fmt.Sprintf("/admin/fulfillment_orders/%d/release_hold.json", ID), struct{}{})
+}
+
+// Cancel cancels the fulfillment order. Its line items are moved to a new
+// fulfillment order, which is returned.
+func (s *FulfillmentOrderService) Cancel(ctx context.Context, ID int64) (*FulfillmentOrder, *http.Response, error) {
+	req, err := s.client.NewRequest("POST", fmt.Sprintf("/admin/fulfillment_orders/%d/cancel.json", ID), struct{}{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var cancelWrapper struct {
+		ReplacementFulfillmentOrder *FulfillmentOrder `json:"replacement_fulfillment_order"`
+	}
+	resp, err := s.client.Do(ctx, req, &cancelWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return cancelWrapper.ReplacementFulfillmentOrder, resp, nil
+}
+
+// Close marks an in progress fulfillment order as incomplete, with an
+// optional message.
+func (s *FulfillmentOrderService) Close(ctx context.Context, ID int64, message string) (*FulfillmentOrder, *http.Response, error) {
+	type closeRequest struct {
+		Message string `json:"message,omitempty"`
+	}
+	return s.post(ctx, fmt.Sprintf("/admin/fulfillment_orders/%d/close.json", ID), struct {
+		FulfillmentOrder *closeRequest `json:"fulfillment_order"`
+	}{&closeRequest{message}})
+}
+
+func (s *FulfillmentOrderService) post(ctx context.Context, path string, body interface{}) (*FulfillmentOrder, *http.Response, error) {
+	req, err := s.client.NewRequest("POST", path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fulfillmentOrderWrapper := new(FulfillmentOrderRequest)
+	resp, err := s.client.Do(ctx, req, fulfillmentOrderWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return fulfillmentOrderWrapper.FulfillmentOrder, resp, nil
+}
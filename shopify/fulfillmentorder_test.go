@@ -0,0 +1,159 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestFulfillmentOrderService(t *testing.T) {
+	t.Parallel()
+
+	fulfillmentOrder := func(id int64, status string) string {
+		return fmt.Sprintf(`{"id":%d,"order_id":1,"assigned_location_id":3,"status":%q}`, id, status)
+	}
+	inputs := []struct {
+		name     string
+		response string
+		call     func(c *Client) (string, error)
+		path     string
+		body     string
+		result   string
+	}{
+		{
+			name: "move",
+			response: `{"original_fulfillment_order":` + fulfillmentOrder(2, "closed") +
+				`,"moved_fulfillment_order":` + fulfillmentOrder(4, "open") +
+				`,"remaining_fulfillment_order":null}`,
+			call: func(c *Client) (string, error) {
+				m, _, err := c.FulfillmentOrder.Move(context.Background(), 2, 5, []*FulfillmentOrderLineItem{{ID: 6, Quantity: 1}})
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d %d %v", m.OriginalFulfillmentOrder.ID, m.MovedFulfillmentOrder.ID, m.RemainingFulfillmentOrder), nil
+			},
+			path:   "/admin/fulfillment_orders/2/move.json",
+			body:   `{"fulfillment_order":{"new_location_id":5,"fulfillment_order_line_items":[{"id":6,"quantity":1}]}}`,
+			result: "2 4 <nil>",
+		},
+		{
+			name:     "move all",
+			response: `{"original_fulfillment_order":` + fulfillmentOrder(2, "closed") + `,"moved_fulfillment_order":` + fulfillmentOrder(4, "open") + `}`,
+			call: func(c *Client) (string, error) {
+				m, _, err := c.FulfillmentOrder.Move(context.Background(), 2, 5, nil)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d", m.MovedFulfillmentOrder.ID), nil
+			},
+			path:   "/admin/fulfillment_orders/2/move.json",
+			body:   `{"fulfillment_order":{"new_location_id":5}}`,
+			result: "4",
+		},
+		{
+			name:     "hold",
+			response: `{"fulfillment_order":` + fulfillmentOrder(2, "on_hold") + `}`,
+			call: func(c *Client) (string, error) {
+				fo, _, err := c.FulfillmentOrder.Hold(context.Background(), 2, &FulfillmentHold{
+					Reason:      FulfillmentHoldReasonIncorrectAddress,
+					ReasonNotes: "check the zip",
+				})
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d %s", fo.ID, fo.Status), nil
+			},
+			path:   "/admin/fulfillment_orders/2/hold.json",
+			body:   `{"fulfillment_hold":{"reason":"incorrect_address","reason_notes":"check the zip"}}`,
+			result: "2 on_hold",
+		},
+		{
+			name:     "release hold",
+			response: `{"fulfillment_order":` + fulfillmentOrder(2, "open") + `}`,
+			call: func(c *Client) (string, error) {
+				fo, _, err := c.FulfillmentOrder.ReleaseHold(context.Background(), 2)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d %s", fo.ID, fo.Status), nil
+			},
+			path:   "/admin/fulfillment_orders/2/release_hold.json",
+			body:   `{}`,
+			result: "2 open",
+		},
+		{
+			name:     "cancel",
+			response: `{"fulfillment_order":` + fulfillmentOrder(2, "cancelled") + `,"replacement_fulfillment_order":` + fulfillmentOrder(4, "open") + `}`,
+			call: func(c *Client) (string, error) {
+				fo, _, err := c.FulfillmentOrder.Cancel(context.Background(), 2)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d %s", fo.ID, fo.Status), nil
+			},
+			path:   "/admin/fulfillment_orders/2/cancel.json",
+			body:   `{}`,
+			result: "4 open",
+		},
+		{
+			name:     "close",
+			response: `{"fulfillment_order":` + fulfillmentOrder(2, "incomplete") + `}`,
+			call: func(c *Client) (string, error) {
+				fo, _, err := c.FulfillmentOrder.Close(context.Background(), 2, "out of stock")
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d %s", fo.ID, fo.Status), nil
+			},
+			path:   "/admin/fulfillment_orders/2/close.json",
+			body:   `{"fulfillment_order":{"message":"out of stock"}}`,
+			result: "2 incomplete",
+		},
+		{
+			name:     "close without message",
+			response: `{"fulfillment_order":` + fulfillmentOrder(2, "incomplete") + `}`,
+			call: func(c *Client) (string, error) {
+				fo, _, err := c.FulfillmentOrder.Close(context.Background(), 2, "")
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("%d %s", fo.ID, fo.Status), nil
+			},
+			path:   "/admin/fulfillment_orders/2/close.json",
+			body:   `{"fulfillment_order":{}}`,
+			result: "2 incomplete",
+		},
+	}
+
+	for _, tt := range inputs {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c, last, teardown := setup(http.StatusOK, tt.response)
+			defer teardown()
+
+			result, err := tt.call(c)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			r := last()
+			if r.Method != "POST" || r.Path != tt.path {
+				t.Errorf("unexpected request %s %s", r.Method, r.Path)
+			}
+			if body := strings.TrimSpace(r.Body); body != tt.body {
+				t.Errorf("expected body %s got %s", tt.body, body)
+			}
+			if result != tt.result {
+				t.Errorf("expected %q got %q", tt.result, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// api reference: https://help.shopify.com/en/api/reference/inventory/inventoryitem
+
+type InventoryItemService service
+
+// InventoryItem is the physical good behind a variant, see
+// Variant.InventoryItemID.
+type InventoryItem struct {
+	ID                   int64  `json:"id,omitempty"`
+	Sku                  string `json:"sku,omitempty"`
+	Cost                 string `json:"cost,omitempty"`
+	Tracked              bool   `json:"tracked,omitempty"`
+	RequiresShipping     bool   `json:"requires_shipping,omitempty"`
+	CountryCodeOfOrigin  string `json:"country_code_of_origin,omitempty"`
+	ProvinceCodeOfOrigin string `json:"province_code_of_origin,omitempty"`
+	HarmonizedSystemCode string `json:"harmonized_system_code,omitempty"`
+
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+type InventoryItemParam struct {
+	IDs      []int64
+	Limit    int
+	PageInfo string
+}
+
+type InventoryItemRequest struct {
+	InventoryItem *InventoryItem `json:"inventory_item"`
+}
+
+func (p *InventoryItemParam) EncodeQuery() string {
+	if p == nil {
+		return ""
+	}
+	v := url.Values{}
+	if p.Limit > 0 {
+		v.Add("limit", fmt.Sprintf("%d", p.Limit))
+	}
+	if len(p.IDs) > 0 {
+		v.Add("ids", joinIDs(p.IDs))
+	}
+	return encodeQuery(v, p.PageInfo)
+}
+
+// List returns the inventory items of params.IDs, which is required.
+func (s *InventoryItemService) List(ctx context.Context, params *InventoryItemParam) ([]*InventoryItem, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "/admin/inventory_items.json", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.URL.RawQuery = params.EncodeQuery()
+
+	var itemWrapper struct {
+		InventoryItems []*InventoryItem `json:"inventory_items"`
+	}
+	resp, err := s.client.Do(ctx, req, &itemWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return itemWrapper.InventoryItems, resp, nil
+}
+
+// InventoryItemIterator iterates over inventory items, see Pagination.
+type InventoryItemIterator struct{ iterator }
+
+// Value returns the current inventory item.
+func (it *InventoryItemIterator) Value() *InventoryItem {
+	v, _ := it.value().(*InventoryItem)
+	return v
+}
+
+// Iter returns an iterator over every inventory item of params.IDs.
+func (s *InventoryItemService) Iter(params *InventoryItemParam) *InventoryItemIterator {
+	var pp InventoryItemParam
+	if params != nil {
+		pp = *params
+	}
+	return &InventoryItemIterator{newIterator(func(ctx context.Context, pageInfo string) (interface{}, *http.Response, error) {
+		pp.PageInfo = pageInfo
+		return s.List(ctx, &pp)
+	})}
+}
+
+func (s *InventoryItemService) Get(ctx context.Context, ID int64) (*InventoryItem, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("/admin/inventory_items/%d.json", ID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	itemWrapper := new(InventoryItemRequest)
+	resp, err := s.client.Do(ctx, req, itemWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return itemWrapper.InventoryItem, resp, nil
+}
+
+// InventoryItemUpdate is a partial update of an inventory item. Only the
+// fields that are set are sent, see VariantUpdate.
+type InventoryItemUpdate struct {
+	ID                   int64       `json:"id"`
+	Sku                  *string     `json:"sku,omitempty"`
+	Cost                 *NullString `json:"cost,omitempty"`
+	Tracked              *bool       `json:"tracked,omitempty"`
+	RequiresShipping     *bool       `json:"requires_shipping,omitempty"`
+	CountryCodeOfOrigin  *NullString `json:"country_code_of_origin,omitempty"`
+	ProvinceCodeOfOrigin *NullString `json:"province_code_of_origin,omitempty"`
+	HarmonizedSystemCode *NullString `json:"harmonized_system_code,omitempty"`
+}
+
+// Update updates the inventory item with the fields that are set, ie. the sku
+// or cost.
+func (s *InventoryItemService) Update(ctx context.Context, item *InventoryItemUpdate) (*InventoryItem, *http.Response, error) {
+	body := struct {
+		InventoryItem *InventoryItemUpdate `json:"inventory_item"`
+	}{item}
+	req, err := s.client.NewRequest(
+		"PUT",
+		fmt.Sprintf("/admin/inventory_items/%d.json", item.ID),
+		&body,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	itemWrapper := new(InventoryItemRequest)
+	resp, err := s.client.Do(ctx, req, itemWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return itemWrapper.InventoryItem, resp, nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestInventoryItemService(t *testing.T) {
+	t.Parallel()
+
+	item := `{"id":1,"sku":"IPOD2008","cost":"25.00","tracked":true}`
+	inputs := []struct {
+		name     string
+		response string
+		call     func(c *Client) (*InventoryItem, error)
+		method   string
+		path     string
+		query    string
+		body     string
+	}{
+		{
+			name:     "list",
+			response: `{"inventory_items":[` + item + `]}`,
+			call: func(c *Client) (*InventoryItem, error) {
+				items, _, err := c.InventoryItem.List(context.Background(), &InventoryItemParam{IDs: []int64{1, 2}})
+				if len(items) != 1 {
+					return nil, err
+				}
+				return items[0], err
+			},
+			method: "GET",
+			path:   "/admin/inventory_items.json",
+			query:  "ids=1%2C2",
+		},
+		{
+			name:     "get",
+			response: `{"inventory_item":` + item + `}`,
+			call: func(c *Client) (*InventoryItem, error) {
+				i, _, err := c.InventoryItem.Get(context.Background(), 1)
+				return i, err
+			},
+			method: "GET",
+			path:   "/admin/inventory_items/1.json",
+		},
+		{
+			name:     "update",
+			response: `{"inventory_item":` + item + `}`,
+			call: func(c *Client) (*InventoryItem, error) {
+				i, _, err := c.InventoryItem.Update(context.Background(), &InventoryItemUpdate{
+					ID:                  1,
+					Cost:                NullableString("25.00"),
+					RequiresShipping:    Bool(false),
+					CountryCodeOfOrigin: &NullString{},
+				})
+				return i, err
+			},
+			method: "PUT",
+			path:   "/admin/inventory_items/1.json",
+			body:   `{"inventory_item":{"id":1,"cost":"25.00","requires_shipping":false,"country_code_of_origin":null}}`,
+		},
+	}
+
+	for _, tt := range inputs {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c, last, teardown := setup(http.StatusOK, tt.response)
+			defer teardown()
+
+			i, err := tt.call(c)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			r := last()
+			if r.Method != tt.method || r.Path != tt.path || r.Query != tt.query {
+				t.Errorf("unexpected request %s %s?%s", r.Method, r.Path, r.Query)
+			}
+			if body := strings.TrimSpace(r.Body); tt.body != "" && body != tt.body {
+				t.Errorf("expected body %s got %s", tt.body, body)
+			}
+			if i == nil || i.ID != 1 || i.Sku != "IPOD2008" || i.Cost != "25.00" || !i.Tracked {
+				t.Errorf("unexpected inventory item %+v", i)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// api reference: https://help.shopify.com/en/api/reference/inventory/inventorylevel
+
+type InventoryLevelService service
+
+// InventoryLevel is the quantity of an inventory item available at a
+// location.
+type InventoryLevel struct {
+	InventoryItemID int64 `json:"inventory_item_id"`
+	LocationID      int64 `json:"location_id"`
+	Available       int   `json:"available"`
+
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+type InventoryLevelParam struct {
+	InventoryItemIDs []int64
+	LocationIDs      []int64
+	Limit            int
+	UpdatedAtMin     *time.Time
+	PageInfo         string
+}
+
+func (p *InventoryLevelParam) EncodeQuery() string {
+	if p == nil {
+		return ""
+	}
+	v := url.Values{}
+	if p.Limit > 0 {
+		v.Add("limit", fmt.Sprintf("%d", p.Limit))
+	}
+	if len(p.InventoryItemIDs) > 0 {
+		v.Add("inventory_item_ids", joinIDs(p.InventoryItemIDs))
+	}
+	if len(p.LocationIDs) > 0 {
+		v.Add("location_ids", joinIDs(p.LocationIDs))
+	}
+	if p.UpdatedAtMin != nil {
+		v.Add("updated_at_min", p.UpdatedAtMin.Format(timeFormat))
+	}
+	return encodeQuery(v, p.PageInfo)
+}
+
+func joinIDs(ids []int64) string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = fmt.Sprintf("%d", id)
+	}
+	return strings.Join(s, ",")
+}
+
+// List returns the inventory levels of the items or locations of params,
+// either of which is required.
+func (s *InventoryLevelService) List(ctx context.Context, params *InventoryLevelParam) ([]*InventoryLevel, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "/admin/inventory_levels.json", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.URL.RawQuery = params.EncodeQuery()
+
+	var levelWrapper struct {
+		InventoryLevels []*InventoryLevel `json:"inventory_levels"`
+	}
+	resp, err := s.client.Do(ctx, req, &levelWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return levelWrapper.InventoryLevels, resp, nil
+}
+
+// InventoryLevelIterator iterates over inventory levels, see Pagination.
+type InventoryLevelIterator struct{ iterator }
+
+// Value returns the current inventory level.
+func (it *InventoryLevelIterator) Value() *InventoryLevel {
+	v, _ := it.value().(*InventoryLevel)
+	return v
+}
+
+// Iter returns an iterator over every inventory level of the items or
+// locations of params.
+func (s *InventoryLevelService) Iter(params *InventoryLevelParam) *InventoryLevelIterator {
+	var pp InventoryLevelParam
+	if params != nil {
+		pp = *params
+	}
+	return &InventoryLevelIterator{newIterator(func(ctx context.Context, pageInfo string) (interface{}, *http.Response, error) {
+		pp.PageInfo = pageInfo
+		return s.List(ctx, &pp)
+	})}
+}
+
+// Adjust adds adjustment, which can be negative, to the quantity available
+// of the item at the location.
+func (s *InventoryLevelService) Adjust(ctx context.Context, inventoryItemID, locationID int64, adjustment int) (*InventoryLevel, *http.Response, error) {
+	return s.post(ctx, "/admin/inventory_levels/adjust.json", struct {
+		InventoryItemID     int64 `json:"inventory_item_id"`
+		LocationID          int64 `json:"location_id"`
+		AvailableAdjustment int   `json:"available_adjustment"`
+	}{inventoryItemID, locationID, adjustment})
+}
+
+// Set sets the quantity available of the item at the location. If the item
+// is not stocked at the location, it is connected to it. When
+// disconnectIfNecessary is set, the item is disconnected from any location
+// that can't stock it along with this one.
+func (s *InventoryLevelService) Set(ctx context.Context, inventoryItemID, locationID int64, available int, disconnectIfNecessary bool) (*InventoryLevel, *http.Response, error) {
+	return s.post(ctx, "/admin/inventory_levels/set.json", struct {
+		InventoryItemID       int64 `json:"inventory_item_id"`
+		LocationID            int64 `json:"location_id"`
+		Available             int   `json:"available"`
+		DisconnectIfNecessary bool  `json:"disconnect_if_necessary,omitempty"`
+	}{inventoryItemID, locationID, available, disconnectIfNecessary})
+}
+
+// Connect stocks the item at the location. When relocateIfNecessary is set,
+// the item is moved off any fulfillment service location it is stocked at.
+func (s *InventoryLevelService) Connect(ctx context.Context, inventoryItemID, locationID int64, relocateIfNecessary bool) (*InventoryLevel, *http.Response, error) {
+	return s.post(ctx, "/admin/inventory_levels/connect.json", struct {
+		InventoryItemID     int64 `json:"inventory_item_id"`
+		LocationID          int64 `json:"location_id"`
+		RelocateIfNecessary bool  `json:"relocate_if_necessary,omitempty"`
+	}{inventoryItemID, locationID, relocateIfNecessary})
+}
+
+func (s *InventoryLevelService) post(ctx context.Context, path string, body interface{}) (*InventoryLevel, *http.Response, error) {
+	req, err := s.client.NewRequest("POST", path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var levelWrapper struct {
+		InventoryLevel *InventoryLevel `json:"inventory_level"`
+	}
+	resp, err := s.client.Do(ctx, req, &levelWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return levelWrapper.InventoryLevel, resp, nil
+}
+
+// Delete removes the item from the location. An item must be stocked at at
+// least one location.
+func (s *InventoryLevelService) Delete(ctx context.Context, inventoryItemID, locationID int64) (*http.Response, error) {
+	req, err := s.client.NewRequest("DELETE", "/admin/inventory_levels.json", nil)
+	if err != nil {
+		return nil, err
+	}
+	v := url.Values{}
+	v.Add("inventory_item_id", fmt.Sprintf("%d", inventoryItemID))
+	v.Add("location_id", fmt.Sprintf("%d", locationID))
+	req.URL.RawQuery = v.Encode()
+
+	return s.client.Do(ctx, req, nil)
+}
@@ -0,0 +1,126 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestInventoryLevelService(t *testing.T) {
+	t.Parallel()
+
+	level := `{"inventory_level":{"inventory_item_id":1,"location_id":2,"available":5}}`
+	inputs := []struct {
+		name     string
+		response string
+		call     func(c *Client) (*InventoryLevel, error)
+		method   string
+		path     string
+		query    string
+		body     string
+	}{
+		{
+			name:     "list",
+			response: `{"inventory_levels":[{"inventory_item_id":1,"location_id":2,"available":5}]}`,
+			call: func(c *Client) (*InventoryLevel, error) {
+				levels, _, err := c.InventoryLevel.List(context.Background(), &InventoryLevelParam{LocationIDs: []int64{2, 3}})
+				if len(levels) != 1 {
+					return nil, err
+				}
+				return levels[0], err
+			},
+			method: "GET",
+			path:   "/admin/inventory_levels.json",
+			query:  "location_ids=2%2C3",
+		},
+		{
+			name:     "adjust",
+			response: level,
+			call: func(c *Client) (*InventoryLevel, error) {
+				l, _, err := c.InventoryLevel.Adjust(context.Background(), 1, 2, -3)
+				return l, err
+			},
+			method: "POST",
+			path:   "/admin/inventory_levels/adjust.json",
+			body:   `{"inventory_item_id":1,"location_id":2,"available_adjustment":-3}`,
+		},
+		{
+			name:     "set",
+			response: level,
+			call: func(c *Client) (*InventoryLevel, error) {
+				l, _, err := c.InventoryLevel.Set(context.Background(), 1, 2, 5, true)
+				return l, err
+			},
+			method: "POST",
+			path:   "/admin/inventory_levels/set.json",
+			body:   `{"inventory_item_id":1,"location_id":2,"available":5,"disconnect_if_necessary":true}`,
+		},
+		{
+			name:     "set zero",
+			response: level,
+			call: func(c *Client) (*InventoryLevel, error) {
+				l, _, err := c.InventoryLevel.Set(context.Background(), 1, 2, 0, false)
+				return l, err
+			},
+			method: "POST",
+			path:   "/admin/inventory_levels/set.json",
+			body:   `{"inventory_item_id":1,"location_id":2,"available":0}`,
+		},
+		{
+			name:     "connect",
+			response: level,
+			call: func(c *Client) (*InventoryLevel, error) {
+				l, _, err := c.InventoryLevel.Connect(context.Background(), 1, 2, false)
+				return l, err
+			},
+			method: "POST",
+			path:   "/admin/inventory_levels/connect.json",
+			body:   `{"inventory_item_id":1,"location_id":2}`,
+		},
+		{
+			name:     "delete",
+			response: `{}`,
+			call: func(c *Client) (*InventoryLevel, error) {
+				_, err := c.InventoryLevel.Delete(context.Background(), 1, 2)
+				return nil, err
+			},
+			method: "DELETE",
+			path:   "/admin/inventory_levels.json",
+			query:  "inventory_item_id=1&location_id=2",
+		},
+	}
+
+	for _, tt := range inputs {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c, last, teardown := setup(http.StatusOK, tt.response)
+			defer teardown()
+
+			l, err := tt.call(c)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			r := last()
+			if r.Method != tt.method || r.Path != tt.path || r.Query != tt.query {
+				t.Errorf("unexpected request %s %s?%s", r.Method, r.Path, r.Query)
+			}
+			if body := strings.TrimSpace(r.Body); tt.body != "" && body != tt.body {
+				t.Errorf("expected body %s got %s", tt.body, body)
+			}
+			if tt.method == "DELETE" {
+				return
+			}
+			if l == nil || l.InventoryItemID != 1 || l.LocationID != 2 || l.Available != 5 {
+				t.Errorf("unexpected inventory level %+v", l)
+			}
+		})
+	}
+}
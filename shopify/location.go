@@ -0,0 +1,108 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// api reference: https://help.shopify.com/en/api/reference/inventory/location
+
+type LocationService service
+
+type Location struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	Address1     string `json:"address1"`
+	Address2     string `json:"address2"`
+	City         string `json:"city"`
+	Zip          string `json:"zip"`
+	Province     string `json:"province"`
+	ProvinceCode string `json:"province_code"`
+	Country      string `json:"country"`
+	CountryCode  string `json:"country_code"`
+	Phone        string `json:"phone"`
+	Active       bool   `json:"active"`
+	// Legacy locations are fulfillment services
+	Legacy bool `json:"legacy"`
+
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func (s *LocationService) List(ctx context.Context) ([]*Location, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "/admin/locations.json", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var locationWrapper struct {
+		Locations []*Location `json:"locations"`
+	}
+	resp, err := s.client.Do(ctx, req, &locationWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return locationWrapper.Locations, resp, nil
+}
+
+func (s *LocationService) Count(ctx context.Context) (int, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "/admin/locations/count.json", nil)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var locationCount struct {
+		Count int `json:"count"`
+	}
+	resp, err := s.client.Do(ctx, req, &locationCount)
+	if err != nil {
+		return 0, resp, err
+	}
+
+	return locationCount.Count, resp, nil
+}
+
+func (s *LocationService) Get(ctx context.Context, ID int64) (*Location, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("/admin/locations/%d.json", ID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var locationWrapper struct {
+		Location *Location `json:"location"`
+	}
+	resp, err := s.client.Do(ctx, req, &locationWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return locationWrapper.Location, resp, nil
+}
+
+// InventoryLevels returns the inventory levels of every item stocked at the
+// location.
+func (s *LocationService) InventoryLevels(ctx context.Context, ID int64, params *InventoryLevelParam) ([]*InventoryLevel, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("/admin/locations/%d/inventory_levels.json", ID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.URL.RawQuery = params.EncodeQuery()
+
+	var levelWrapper struct {
+		InventoryLevels []*InventoryLevel `json:"inventory_levels"`
+	}
+	resp, err := s.client.Do(ctx, req, &levelWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return levelWrapper.InventoryLevels, resp, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestLocationService(t *testing.T) {
+	t.Parallel()
+
+	location := `{"id":2,"name":"Warehouse","city":"Ottawa","active":true}`
+	inputs := []struct {
+		name     string
+		response string
+		call     func(c *Client) (int, error)
+		method   string
+		path     string
+		query    string
+		expected int
+	}{
+		{
+			name:     "list",
+			response: `{"locations":[` + location + `]}`,
+			call: func(c *Client) (int, error) {
+				locations, _, err := c.Location.List(context.Background())
+				if err != nil || len(locations) != 1 || locations[0].Name != "Warehouse" {
+					return 0, err
+				}
+				return len(locations), nil
+			},
+			method:   "GET",
+			path:     "/admin/locations.json",
+			expected: 1,
+		},
+		{
+			name:     "count",
+			response: `{"count":3}`,
+			call: func(c *Client) (int, error) {
+				count, _, err := c.Location.Count(context.Background())
+				return count, err
+			},
+			method:   "GET",
+			path:     "/admin/locations/count.json",
+			expected: 3,
+		},
+		{
+			name:     "get",
+			response: `{"location":` + location + `}`,
+			call: func(c *Client) (int, error) {
+				l, _, err := c.Location.Get(context.Background(), 2)
+				if err != nil || !l.Active {
+					return 0, err
+				}
+				return int(l.ID), nil
+			},
+			method:   "GET",
+			path:     "/admin/locations/2.json",
+			expected: 2,
+		},
+		{
+			name:     "inventory levels",
+			response: `{"inventory_levels":[{"inventory_item_id":1,"location_id":2,"available":5}]}`,
+			call: func(c *Client) (int, error) {
+				levels, _, err := c.Location.InventoryLevels(context.Background(), 2, &InventoryLevelParam{Limit: 50})
+				if err != nil || len(levels) != 1 {
+					return 0, err
+				}
+				return levels[0].Available, nil
+			},
+			method:   "GET",
+			path:     "/admin/locations/2/inventory_levels.json",
+			query:    "limit=50",
+			expected: 5,
+		},
+	}
+
+	for _, tt := range inputs {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c, last, teardown := setup(http.StatusOK, tt.response)
+			defer teardown()
+
+			v, err := tt.call(c)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			r := last()
+			if r.Method != tt.method || r.Path != tt.path || r.Query != tt.query {
+				t.Errorf("unexpected request %s %s?%s", r.Method, r.Path, r.Query)
+			}
+			if v != tt.expected {
+				t.Errorf("expected %d got %d", tt.expected, v)
+			}
+		})
+	}
+}
@@ -0,0 +1,454 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Money is a decimal amount of money in a currency. Shopify sends amounts as
+// decimal strings, ie. "10.00", which Money parses without going through
+// floating point, keeping the number of decimals it was given.
+//
+// The zero value is an amount of zero without currency.
+type Money struct {
+	// amount in units of 10^-scale
+	units int64
+	scale int
+
+	// ISO 4217 currency code, ie. "USD"
+	Currency string
+}
+
+var (
+	ErrInvalidMoney     = errors.New("invalid money amount")
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrMoneyOverflow    = errors.New("money amount out of range")
+
+	// moneyFormatTag matches the amount tag of a shop's money format, ie.
+	// "{{amount}}" in "${{amount}}"
+	moneyFormatTag = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+)
+
+// maxScale is the largest number of decimals supported.
+const maxScale = 9
+
+var pow10 = [maxScale + 1]int64{1, 10, 100, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9}
+
+// ParseMoney parses a decimal amount, ie. "10.00" or "-3.5", in the given
+// currency.
+func ParseMoney(amount, currency string) (Money, error) {
+	s := strings.TrimSpace(amount)
+	neg := false
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+	if intPart == "" && fracPart == "" || len(fracPart) > maxScale || !isDigits(intPart) || !isDigits(fracPart) {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidMoney, amount)
+	}
+
+	units, err := strconv.ParseInt(intPart+fracPart, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidMoney, amount)
+	}
+	if neg {
+		units = -units
+	}
+	return Money{units: units, scale: len(fracPart), Currency: currency}, nil
+}
+
+// NewMoney returns the amount of minor units, ie. cents, in a currency with
+// the given number of decimals. NewMoney(1050, 2, "USD") is $10.50.
+func NewMoney(units int64, decimals int, currency string) Money {
+	if decimals < 0 {
+		decimals = 0
+	}
+	if decimals > maxScale {
+		decimals = maxScale
+	}
+	return Money{units: units, scale: decimals, Currency: currency}
+}
+
+// parseMoneyField parses an amount field of a model, where an empty string
+// stands for no amount.
+func parseMoneyField(amount, currency string) (Money, error) {
+	if amount == "" {
+		return Money{Currency: currency}, nil
+	}
+	return ParseMoney(amount, currency)
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// rescale returns the units of m at a larger or equal scale.
+func (m Money) rescale(scale int) (int64, error) {
+	return mulUnits(m.units, pow10[scale-m.scale])
+}
+
+// addUnits returns a+b, or ErrMoneyOverflow if it doesn't fit in an int64.
+func addUnits(a, b int64) (int64, error) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, ErrMoneyOverflow
+	}
+	return a + b, nil
+}
+
+// mulUnits returns a*b, or ErrMoneyOverflow if it doesn't fit in an int64.
+func mulUnits(a, b int64) (int64, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	r := a * b
+	if r/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, ErrMoneyOverflow
+	}
+	return r, nil
+}
+
+// align returns the units of m and o at the same scale, and that scale.
+func (m Money) align(o Money) (int64, int64, int, error) {
+	if m.Currency != "" && o.Currency != "" && m.Currency != o.Currency {
+		return 0, 0, 0, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	scale := m.scale
+	if o.scale > scale {
+		scale = o.scale
+	}
+	a, err := m.rescale(scale)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	b, err := o.rescale(scale)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return a, b, scale, nil
+}
+
+func (m Money) currency(o Money) string {
+	if m.Currency != "" {
+		return m.Currency
+	}
+	return o.Currency
+}
+
+// Add returns m+o. Both amounts must be in the same currency, an amount
+// without currency adds to any. ErrMoneyOverflow is returned if the sum is
+// out of range.
+func (m Money) Add(o Money) (Money, error) {
+	a, b, scale, err := m.align(o)
+	if err != nil {
+		return Money{}, err
+	}
+	units, err := addUnits(a, b)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{units: units, scale: scale, Currency: m.currency(o)}, nil
+}
+
+// Sub returns m-o. Both amounts must be in the same currency, an amount
+// without currency subtracts from any. ErrMoneyOverflow is returned if the
+// difference is out of range.
+func (m Money) Sub(o Money) (Money, error) {
+	a, b, scale, err := m.align(o)
+	if err != nil {
+		return Money{}, err
+	}
+	if b == math.MinInt64 {
+		return Money{}, ErrMoneyOverflow
+	}
+	units, err := addUnits(a, -b)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{units: units, scale: scale, Currency: m.currency(o)}, nil
+}
+
+// Mul returns m*n, ie. the price of n items. ErrMoneyOverflow is returned if
+// the product is out of range.
+func (m Money) Mul(n int64) (Money, error) {
+	units, err := mulUnits(m.units, n)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{units: units, scale: m.scale, Currency: m.Currency}, nil
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money {
+	return Money{units: -m.units, scale: m.scale, Currency: m.Currency}
+}
+
+// Cmp compares m and o and returns -1 if m < o, 0 if m == o and +1 if m > o.
+func (m Money) Cmp(o Money) (int, error) {
+	a, b, _, err := m.align(o)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case a < b:
+		return -1, nil
+	case a > b:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// IsZero reports whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.units == 0
+}
+
+// IsNegative reports whether the amount is below zero.
+func (m Money) IsNegative() bool {
+	return m.units < 0
+}
+
+// Round rounds the amount to the given number of decimals, half away from
+// zero. An amount too large to hold more decimals is returned as is.
+func (m Money) Round(decimals int) Money {
+	if decimals < 0 {
+		decimals = 0
+	}
+	if decimals >= m.scale {
+		if decimals > maxScale {
+			decimals = maxScale
+		}
+		units, err := m.rescale(decimals)
+		if err != nil {
+			return m
+		}
+		return Money{units: units, scale: decimals, Currency: m.Currency}
+	}
+
+	p := pow10[m.scale-decimals]
+	units, rem := m.units/p, m.units%p
+	if rem < 0 {
+		rem = -rem
+	}
+	if rem*2 >= p {
+		if m.units < 0 {
+			units--
+		} else {
+			units++
+		}
+	}
+	return Money{units: units, scale: decimals, Currency: m.Currency}
+}
+
+// String returns the amount as a decimal string, ie. "10.00", the format
+// used by Shopify.
+func (m Money) String() string {
+	s, negative := m.format(m.scale, "", ".")
+	if negative {
+		return "-" + s
+	}
+	return s
+}
+
+// format returns the absolute amount with the given number of decimals,
+// using thousands as the digit group separator if not empty. It reports
+// whether the rounded amount is negative: an amount rounding to zero has no
+// sign.
+func (m Money) format(decimals int, thousands, decimal string) (string, bool) {
+	r := m
+	if decimals < m.scale {
+		r = m.Round(decimals)
+	}
+	// the digits of the amount, at least one before the decimal point
+	digits := strconv.FormatInt(r.units, 10)
+	digits = strings.TrimPrefix(digits, "-")
+	if len(digits) <= r.scale {
+		digits = strings.Repeat("0", r.scale-len(digits)+1) + digits
+	}
+	intPart, fracPart := digits[:len(digits)-r.scale], digits[len(digits)-r.scale:]
+
+	var b strings.Builder
+	for i, c := range intPart {
+		if i > 0 && thousands != "" && (len(intPart)-i)%3 == 0 {
+			b.WriteString(thousands)
+		}
+		b.WriteRune(c)
+	}
+	if decimals > 0 {
+		b.WriteString(decimal)
+		b.WriteString(fracPart)
+		b.WriteString(strings.Repeat("0", decimals-len(fracPart)))
+	}
+	return b.String(), r.IsNegative()
+}
+
+// Format formats the amount with a shop's money format, ie. "${{amount}}" or
+// "{{amount_with_comma_separator}} €". The sign of a negative amount leads
+// the currency symbol, ie. "-$10.00".
+//
+// Shopify API docs: https://help.shopify.com/en/manual/payments/currency-formatting
+func (m Money) Format(format string) string {
+	var negative bool
+	amount := func(decimals int, thousands, decimal string) string {
+		s, neg := m.format(decimals, thousands, decimal)
+		negative = negative || neg
+		return s
+	}
+	formatted := moneyFormatTag.ReplaceAllStringFunc(format, func(tag string) string {
+		switch moneyFormatTag.FindStringSubmatch(tag)[1] {
+		case "amount":
+			return amount(2, ",", ".")
+		case "amount_no_decimals":
+			return amount(0, ",", ".")
+		case "amount_with_comma_separator":
+			return amount(2, ".", ",")
+		case "amount_no_decimals_with_comma_separator":
+			return amount(0, ".", ",")
+		case "amount_with_apostrophe_separator":
+			return amount(2, "'", ".")
+		case "amount_no_decimals_with_space_separator":
+			return amount(0, " ", ",")
+		case "amount_with_space_separator":
+			return amount(2, " ", ",")
+		}
+		return tag
+	})
+	if negative {
+		return "-" + formatted
+	}
+	return formatted
+}
+
+// MarshalJSON satisfies json.Marshaler. The amount is encoded as a decimal
+// string, ie. "10.00".
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON satisfies json.Unmarshaler. It accepts a decimal string, a
+// number or null. The currency of m is left untouched.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*m = Money{Currency: m.Currency}
+		return nil
+	}
+	amount := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &amount); err != nil {
+			return err
+		}
+	}
+	mm, err := parseMoneyField(amount, m.Currency)
+	if err != nil {
+		return err
+	}
+	*m = mm
+	return nil
+}
+
+// FormatMoney formats the amount with the shop's money format.
+func (s *Shop) FormatMoney(m Money) string {
+	return m.Format(s.MoneyFormat)
+}
+
+// FormatMoneyWithCurrency formats the amount with the shop's money with
+// currency format, ie. "${{amount}} USD".
+func (s *Shop) FormatMoneyWithCurrency(m Money) string {
+	return m.Format(s.MoneyWithCurrencyFormat)
+}
+
+// TotalPriceMoney returns the total price of the checkout.
+func (c *Checkout) TotalPriceMoney() (Money, error) {
+	return parseMoneyField(c.TotalPrice, c.Currency)
+}
+
+// SubtotalPriceMoney returns the subtotal price of the checkout.
+func (c *Checkout) SubtotalPriceMoney() (Money, error) {
+	return parseMoneyField(c.SubtotalPrice, c.Currency)
+}
+
+// TotalTaxMoney returns the total tax of the checkout.
+func (c *Checkout) TotalTaxMoney() (Money, error) {
+	return parseMoneyField(c.TotalTax, c.Currency)
+}
+
+// PaymentDueMoney returns the amount left to pay on the checkout.
+func (c *Checkout) PaymentDueMoney() (Money, error) {
+	return parseMoneyField(c.PaymentDue, c.Currency)
+}
+
+// PriceMoney returns the price of the variant, in the shop's currency.
+func (v *ProductVariant) PriceMoney(currency string) (Money, error) {
+	return parseMoneyField(v.Price, currency)
+}
+
+// CompareAtPriceMoney returns the compare at price of the variant, in the
+// shop's currency. It is zero if the variant has none.
+func (v *ProductVariant) CompareAtPriceMoney(currency string) (Money, error) {
+	return parseMoneyField(v.CompareAtPrice, currency)
+}
+
+// PriceMoney returns the price of the variant, in the shop's currency.
+func (v *Variant) PriceMoney(currency string) (Money, error) {
+	return parseMoneyField(v.Price, currency)
+}
+
+// CompareAtPriceMoney returns the compare at price of the variant, in the
+// shop's currency. It is zero if the variant has none.
+func (v *Variant) CompareAtPriceMoney(currency string) (Money, error) {
+	return parseMoneyField(v.CompareAtPrice, currency)
+}
+
+// PriceMoney returns the price of the shipping rate, in the shop's currency.
+func (r *ShippingZoneRate) PriceMoney(currency string) (Money, error) {
+	return parseMoneyField(r.Price, currency)
+}
+
+// PriceMoney returns the price of the application charge. App charges are
+// always billed in USD.
+func (b *Billing) PriceMoney() (Money, error) {
+	return parseMoneyField(b.Price, "USD")
+}
+
+// PriceMoney returns the price of the usage charge. App charges are always
+// billed in USD.
+func (c *UsageCharge) PriceMoney() (Money, error) {
+	return parseMoneyField(c.Price, "USD")
+}
+
+// SubtotalMoney returns the subtotal of the refunded items, in the currency
+// of the order.
+func (i *RefundLineItem) SubtotalMoney(currency string) (Money, error) {
+	return parseMoneyField(i.Subtotal.String(), currency)
+}
+
+// TotalTaxMoney returns the tax of the refunded items, in the currency of the
+// order.
+func (i *RefundLineItem) TotalTaxMoney(currency string) (Money, error) {
+	return parseMoneyField(i.TotalTax.String(), currency)
+}
+
+// AmountMoney returns the amount of the transaction.
+func (t *Transaction) AmountMoney() (Money, error) {
+	return parseMoneyField(t.Amount, t.Currency)
+}
@@ -0,0 +1,184 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	t.Parallel()
+
+	inputs := []struct {
+		input    string
+		expected string
+		err      bool
+	}{
+		{"10.00", "10.00", false},
+		{"-3.5", "-3.5", false},
+		{"+0.10", "0.10", false},
+		{"1000", "1000", false},
+		{".5", "0.5", false},
+		{"", "", true},
+		{".", "", true},
+		{"1.2.3", "", true},
+		{"1,000.00", "", true},
+		{"12abc", "", true},
+	}
+
+	for _, tt := range inputs {
+		m, err := ParseMoney(tt.input, "USD")
+		if tt.err {
+			if !errors.Is(err, ErrInvalidMoney) {
+				t.Errorf("%q: expected invalid money, got %v", tt.input, err)
+			}
+			continue
+		}
+		if err != nil || m.String() != tt.expected {
+			t.Errorf("%q: expected %s got %s (%v)", tt.input, tt.expected, m, err)
+		}
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	t.Parallel()
+
+	a, _ := ParseMoney("0.10", "USD")
+	b, _ := ParseMoney("0.2", "USD")
+
+	sum, err := a.Add(b)
+	if err != nil || sum.String() != "0.30" {
+		t.Errorf("expected 0.30 got %s (%v)", sum, err)
+	}
+	diff, _ := a.Sub(b)
+	if diff.String() != "-0.10" || !diff.IsNegative() {
+		t.Errorf("expected -0.10 got %s", diff)
+	}
+	triple, err := a.Mul(3)
+	if err != nil || triple.String() != "0.30" {
+		t.Errorf("expected 0.30 got %s (%v)", triple, err)
+	}
+	if c, _ := sum.Cmp(triple); c != 0 {
+		t.Errorf("expected 0.1+0.2 to equal 0.1*3, got %d", c)
+	}
+	if c, _ := a.Cmp(b); c != -1 {
+		t.Errorf("expected 0.10 < 0.2, got %d", c)
+	}
+
+	// aligning the scales of large amounts must not wrap around
+	large, _ := ParseMoney("9223372036854775.807", "USD")
+	inputs := []struct {
+		name string
+		op   func() (Money, error)
+	}{
+		{"add", func() (Money, error) { return large.Add(large) }},
+		{"align", func() (Money, error) { return large.Add(NewMoney(1, 9, "USD")) }},
+		{"sub", func() (Money, error) { return large.Neg().Sub(large) }},
+		{"mul", func() (Money, error) { return large.Mul(2) }},
+	}
+	for _, tt := range inputs {
+		if m, err := tt.op(); !errors.Is(err, ErrMoneyOverflow) {
+			t.Errorf("%s: expected ErrMoneyOverflow, got %s (%v)", tt.name, m, err)
+		}
+	}
+
+	eur, _ := ParseMoney("1.00", "EUR")
+	if _, err := a.Add(eur); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("expected currency mismatch, got %v", err)
+	}
+
+	for input, expected := range map[string]string{
+		"1.005":  "1.01",
+		"1.004":  "1.00",
+		"-1.005": "-1.01",
+		"2":      "2.00",
+	} {
+		m, _ := ParseMoney(input, "")
+		if r := m.Round(2); r.String() != expected {
+			t.Errorf("round %s: expected %s got %s", input, expected, r)
+		}
+	}
+}
+
+func TestMoneyFormat(t *testing.T) {
+	t.Parallel()
+
+	m, _ := ParseMoney("1134.655", "USD")
+	inputs := []struct {
+		format   string
+		expected string
+	}{
+		{"${{amount}}", "$1,134.66"},
+		{"${{ amount }} USD", "$1,134.66 USD"},
+		{"${{amount_no_decimals}}", "$1,135"},
+		{"{{amount_with_comma_separator}} €", "1.134,66 €"},
+		{"{{amount_no_decimals_with_comma_separator}} kr", "1.135 kr"},
+		{"{{amount_with_apostrophe_separator}} CHF", "1'134.66 CHF"},
+		{"{{amount_no_decimals_with_space_separator}} zł", "1 135 zł"},
+		{"{{amount_with_space_separator}} zł", "1 134,66 zł"},
+		{"{{unknown}}", "{{unknown}}"},
+	}
+	for _, tt := range inputs {
+		if actual := m.Format(tt.format); actual != tt.expected {
+			t.Errorf("%s: expected %s got %s", tt.format, tt.expected, actual)
+		}
+	}
+
+	// the sign leads the currency symbol
+	neg, _ := ParseMoney("-1234567.5", "USD")
+	if actual := (&Shop{MoneyFormat: "${{amount}}"}).FormatMoney(neg); actual != "-$1,234,567.50" {
+		t.Errorf("expected -$1,234,567.50 got %s", actual)
+	}
+
+	// a negative amount rounding to zero has no sign
+	tiny, _ := ParseMoney("-0.004", "USD")
+	if actual := tiny.Format("${{amount}}"); actual != "$0.00" {
+		t.Errorf("expected $0.00 got %s", actual)
+	}
+	if actual := tiny.Round(2).String(); actual != "0.00" {
+		t.Errorf("expected 0.00 got %s", actual)
+	}
+	if actual := tiny.String(); actual != "-0.004" {
+		t.Errorf("expected -0.004 got %s", actual)
+	}
+
+	// formatting doesn't overflow adding decimals to large amounts
+	large, _ := ParseMoney("9223372036854775807", "USD")
+	if actual := large.Format("${{amount}}"); actual != "$9,223,372,036,854,775,807.00" {
+		t.Errorf("expected $9,223,372,036,854,775,807.00 got %s", actual)
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	t.Parallel()
+
+	var v struct {
+		Price    Money `json:"price"`
+		Number   Money `json:"number"`
+		Null     Money `json:"null"`
+		Discount Money `json:"discount"`
+	}
+	v.Price.Currency = "CAD"
+	if err := json.Unmarshal([]byte(`{"price":"19.99","number":199.0,"null":null,"discount":""}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Price.String() != "19.99" || v.Price.Currency != "CAD" || v.Number.String() != "199.0" || !v.Null.IsZero() || !v.Discount.IsZero() {
+		t.Errorf("unexpected decoding %+v", v)
+	}
+
+	b, _ := json.Marshal(v)
+	expected := `{"price":"19.99","number":"199.0","null":"0","discount":"0"}`
+	if string(b) != expected {
+		t.Errorf("expected %s got %s", expected, b)
+	}
+
+	checkout := &Checkout{TotalPrice: "403.00", Currency: "USD"}
+	if total, err := checkout.TotalPriceMoney(); err != nil || total.String() != "403.00" || total.Currency != "USD" {
+		t.Errorf("unexpected checkout total %+v (%v)", total, err)
+	}
+}
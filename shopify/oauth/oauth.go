@@ -0,0 +1,257 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package oauth implements the install flow of public Shopify apps.
+//
+// The app redirects the merchant to the URL returned by AuthCodeURL. Once
+// they approve the requested scopes, Shopify redirects back to the app's
+// redirect URL, where the callback is verified with VerifyCallback and the
+// authorization code exchanged for an access token with Exchange.
+//
+// Shopify API docs: https://help.shopify.com/en/api/getting-started/authentication/oauth
+package oauth
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidHMAC  = errors.New("oauth: invalid hmac")
+	ErrInvalidShop  = errors.New("oauth: invalid shop hostname")
+	ErrInvalidState = errors.New("oauth: state does not match")
+	ErrMissingCode  = errors.New("oauth: missing authorization code")
+)
+
+// AccessMode is the kind of access token requested.
+type AccessMode string
+
+const (
+	// Offline tokens don't expire and are meant for background work.
+	Offline AccessMode = ""
+	// Online tokens are tied to the merchant's user and expire with their
+	// session.
+	Online AccessMode = "per-user"
+)
+
+var (
+	// shopHostname matches a valid shop hostname, ie. x.myshopify.com
+	shopHostname = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9\-]*\.myshopify\.com$`)
+
+	// escapers of the signed query parameters
+	keyEscaper   = strings.NewReplacer("%", "%25", "&", "%26", "=", "%3D")
+	valueEscaper = strings.NewReplacer("%", "%25", "&", "%26")
+)
+
+// Config describes the app taking part in the install flow.
+type Config struct {
+	// ClientID is the app's API key
+	ClientID string
+	// ClientSecret is the app's shared secret
+	ClientSecret string
+	// Scopes are the access scopes requested, ie. "read_products"
+	Scopes []string
+	// RedirectURL is where Shopify sends the merchant back to after they
+	// approve the app. It must be whitelisted in the app settings.
+	RedirectURL string
+
+	// HTTPClient is used to exchange the authorization code. If nil,
+	// http.DefaultClient is used.
+	HTTPClient *http.Client
+}
+
+// Token is the access token granted to the app.
+type Token struct {
+	AccessToken string `json:"access_token"`
+	Scope       string `json:"scope"`
+
+	// online tokens only
+	ExpiresIn           int             `json:"expires_in,omitempty"`
+	AssociatedUserScope string          `json:"associated_user_scope,omitempty"`
+	AssociatedUser      *AssociatedUser `json:"associated_user,omitempty"`
+
+	// Expiry is the time the token expires at, zero for offline tokens
+	Expiry time.Time `json:"-"`
+}
+
+// AssociatedUser is the merchant's user an online token was granted for.
+type AssociatedUser struct {
+	ID            int64  `json:"id"`
+	FirstName     string `json:"first_name"`
+	LastName      string `json:"last_name"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	AccountOwner  bool   `json:"account_owner"`
+	Locale        string `json:"locale"`
+	Collaborator  bool   `json:"collaborator"`
+}
+
+// Online reports whether the token is an online, per user, token.
+func (t *Token) Online() bool {
+	return t.AssociatedUser != nil
+}
+
+// ValidShop reports whether shop is a valid shop hostname, ie.
+// x.myshopify.com. The shop of a callback must always be validated before
+// sending anything to it.
+func ValidShop(shop string) bool {
+	return shopHostname.MatchString(shop)
+}
+
+// NewState returns a random nonce to pass as the state of AuthCodeURL. The
+// app must keep it, ie. in a cookie, to compare it with the callback's.
+func NewState() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// AuthCodeURL returns the URL of shop to redirect the merchant to, asking them
+// to approve the app's scopes.
+func (c *Config) AuthCodeURL(shop, state string, mode AccessMode) (string, error) {
+	if !ValidShop(shop) {
+		return "", ErrInvalidShop
+	}
+	v := url.Values{}
+	v.Set("client_id", c.ClientID)
+	v.Set("scope", strings.Join(c.Scopes, ","))
+	v.Set("redirect_uri", c.RedirectURL)
+	v.Set("state", state)
+	if mode != Offline {
+		v.Set("grant_options[]", string(mode))
+	}
+	return fmt.Sprintf("https://%s/admin/oauth/authorize?%s", shop, v.Encode()), nil
+}
+
+// VerifyCallback verifies the query of the callback request Shopify redirected
+// the merchant to. It checks the query's HMAC, the shop hostname and that the
+// state matches the one passed to AuthCodeURL.
+func (c *Config) VerifyCallback(query url.Values, state string) error {
+	if !c.ValidHMAC(query) {
+		return ErrInvalidHMAC
+	}
+	if !ValidShop(query.Get("shop")) {
+		return ErrInvalidShop
+	}
+	if !hmac.Equal([]byte(query.Get("state")), []byte(state)) {
+		return ErrInvalidState
+	}
+	if query.Get("code") == "" {
+		return ErrMissingCode
+	}
+	return nil
+}
+
+// ValidHMAC reports whether the hmac parameter of a query signed by Shopify,
+// ie. the OAuth callback or an app proxy request, is valid.
+func (c *Config) ValidHMAC(query url.Values) bool {
+	signature, err := hex.DecodeString(query.Get("hmac"))
+	if err != nil || len(signature) == 0 {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(c.ClientSecret))
+	mac.Write([]byte(signedMessage(query)))
+	return hmac.Equal(signature, mac.Sum(nil))
+}
+
+// signedMessage returns the message signed by Shopify: the query parameters,
+// minus the signatures, sorted by key and joined with "&".
+func signedMessage(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		if k == "hmac" || k == "signature" {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		values := query[k]
+		value := values[0]
+		if len(values) > 1 || strings.HasSuffix(k, "[]") {
+			// arrays are signed as ids=["1", "2"]
+			quoted := make([]string, len(values))
+			for j, v := range values {
+				quoted[j] = fmt.Sprintf("%q", v)
+			}
+			k = strings.TrimSuffix(k, "[]")
+			value = "[" + strings.Join(quoted, ", ") + "]"
+		}
+		pairs[i] = keyEscaper.Replace(k) + "=" + valueEscaper.Replace(value)
+	}
+	return strings.Join(pairs, "&")
+}
+
+// Exchange exchanges the authorization code of a verified callback for an
+// access token of shop.
+func (c *Config) Exchange(ctx context.Context, shop, code string) (*Token, error) {
+	if !ValidShop(shop) {
+		return nil, ErrInvalidShop
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"client_id":     c.ClientID,
+		"client_secret": c.ClientSecret,
+		"code":          code,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", fmt.Sprintf("https://%s/admin/oauth/access_token", shop), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oauth: cannot exchange code: %s: %s", resp.Status, data)
+	}
+
+	token := new(Token)
+	if err := json.Unmarshal(data, token); err != nil {
+		return nil, err
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("oauth: server response missing access_token: %s", data)
+	}
+	if token.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package oauth
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+var testConfig = &Config{
+	ClientID:     "key",
+	ClientSecret: "hush",
+	Scopes:       []string{"read_products", "write_orders"},
+	RedirectURL:  "https://app.example.com/auth/callback",
+}
+
+func signQuery(v url.Values) url.Values {
+	mac := hmac.New(sha256.New, []byte(testConfig.ClientSecret))
+	mac.Write([]byte(signedMessage(v)))
+	v.Set("hmac", hex.EncodeToString(mac.Sum(nil)))
+	return v
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	t.Parallel()
+
+	u, err := testConfig.AuthCodeURL("x.myshopify.com", "nonce", Online)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "https://x.myshopify.com/admin/oauth/authorize?client_id=key&grant_options%5B%5D=per-user&redirect_uri=https%3A%2F%2Fapp.example.com%2Fauth%2Fcallback&scope=read_products%2Cwrite_orders&state=nonce"
+	if u != expected {
+		t.Errorf("expected %s got %s", expected, u)
+	}
+
+	if _, err := testConfig.AuthCodeURL("evil.com/x.myshopify.com", "nonce", Offline); err != ErrInvalidShop {
+		t.Errorf("expected invalid shop, got %v", err)
+	}
+}
+
+func TestSignedMessage(t *testing.T) {
+	t.Parallel()
+
+	// example from the shopify docs
+	q, _ := url.ParseQuery("code=0907a61c0c8d55e99db179b68161bc00&hmac=4712bf92ffc2917d15a2f5a273e39f0116667419aa4b6ac0b3baaf26fa3c4d20&shop=some-shop.myshopify.com&state=0.6784241404160823&timestamp=1337178173")
+	expected := "code=0907a61c0c8d55e99db179b68161bc00&shop=some-shop.myshopify.com&state=0.6784241404160823&timestamp=1337178173"
+	if actual := signedMessage(q); actual != expected {
+		t.Errorf("expected %s got %s", expected, actual)
+	}
+
+	q, _ = url.ParseQuery("ids[]=2&ids[]=1&shop=x.myshopify.com&a%26b=c%3Dd")
+	expected = `a%26b=c=d&ids=["2", "1"]&shop=x.myshopify.com`
+	if actual := signedMessage(q); actual != expected {
+		t.Errorf("expected %s got %s", expected, actual)
+	}
+}
+
+func TestVerifyCallback(t *testing.T) {
+	t.Parallel()
+
+	valid := func() url.Values {
+		return url.Values{
+			"code":      {"abc"},
+			"shop":      {"x.myshopify.com"},
+			"state":     {"nonce"},
+			"timestamp": {"1337178173"},
+		}
+	}
+
+	tampered := signQuery(valid())
+	tampered.Set("shop", "y.myshopify.com")
+
+	badShop := valid()
+	badShop.Set("shop", "x.myshopify.com.evil.com")
+
+	inputs := []struct {
+		name     string
+		query    url.Values
+		expected error
+	}{
+		{"valid", signQuery(valid()), nil},
+		{"unsigned", valid(), ErrInvalidHMAC},
+		{"tampered", tampered, ErrInvalidHMAC},
+		{"bad shop", signQuery(badShop), ErrInvalidShop},
+	}
+	for _, tt := range inputs {
+		if err := testConfig.VerifyCallback(tt.query, "nonce"); err != tt.expected {
+			t.Errorf("%s: expected %v got %v", tt.name, tt.expected, err)
+		}
+	}
+	if err := testConfig.VerifyCallback(signQuery(valid()), "other"); err != ErrInvalidState {
+		t.Errorf("expected state mismatch, got %v", err)
+	}
+}
+
+// rewriteTransport sends every request to the test server.
+type rewriteTransport struct {
+	target *url.URL
+	rt     http.RoundTripper
+}
+
+func (t *rewriteTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r.URL.Scheme = t.target.Scheme
+	r.URL.Host = t.target.Host
+	return t.rt.RoundTrip(r)
+}
+
+func TestExchange(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/admin/oauth/access_token" || r.Host != "x.myshopify.com" {
+			t.Errorf("unexpected request %s %s%s", r.Method, r.Host, r.URL.Path)
+		}
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		switch body["code"] {
+		case "offline":
+			w.Write([]byte(`{"access_token":"f85632530bf277ec9ac6f649fc327f17","scope":"write_orders,read_customers"}`))
+		case "online":
+			w.Write([]byte(`{
+  "access_token": "f85632530bf277ec9ac6f649fc327f17",
+  "scope": "write_orders,read_customers",
+  "expires_in": 86399,
+  "associated_user_scope": "write_orders",
+  "associated_user": {
+    "id": 902541635,
+    "first_name": "John",
+    "last_name": "Smith",
+    "email": "john@example.com",
+    "email_verified": true,
+    "account_owner": true,
+    "locale": "en",
+    "collaborator": false
+  }
+}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_request"}`))
+		}
+	}))
+	defer ts.Close()
+
+	target, _ := url.Parse(ts.URL)
+	config := *testConfig
+	config.HTTPClient = &http.Client{Transport: &rewriteTransport{target, ts.Client().Transport}}
+
+	token, err := config.Exchange(context.Background(), "x.myshopify.com", "offline")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.Online() || !token.Expiry.IsZero() || token.AccessToken == "" {
+		t.Errorf("expected an offline token, got %+v", token)
+	}
+
+	token, err = config.Exchange(context.Background(), "x.myshopify.com", "online")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !token.Online() || token.Expiry.IsZero() || token.AssociatedUser.Email != "john@example.com" {
+		t.Errorf("expected an online token, got %+v", token)
+	}
+
+	if _, err := config.Exchange(context.Background(), "x.myshopify.com", "bad"); err == nil || !strings.Contains(err.Error(), "invalid_request") {
+		t.Errorf("expected exchange error, got %v", err)
+	}
+}
@@ -0,0 +1,425 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// api reference: https://help.shopify.com/en/api/reference/orders/order
+
+type OrderService service
+
+type Order struct {
+	ID            int64  `json:"id,omitempty"`
+	Email         string `json:"email,omitempty"`
+	Phone         string `json:"phone,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Number        int    `json:"number,omitempty"`
+	OrderNumber   int    `json:"order_number,omitempty"`
+	Token         string `json:"token,omitempty"`
+	CartToken     string `json:"cart_token,omitempty"`
+	CheckoutToken string `json:"checkout_token,omitempty"`
+	CheckoutID    int64  `json:"checkout_id,omitempty"`
+
+	Note           string           `json:"note,omitempty"`
+	NoteAttributes []*NoteAttribute `json:"note_attributes,omitempty"`
+	Tags           string           `json:"tags,omitempty"`
+
+	Gateway               string `json:"gateway,omitempty"`
+	SourceName            string `json:"source_name,omitempty"`
+	Test                  bool   `json:"test,omitempty"`
+	Confirmed             bool   `json:"confirmed,omitempty"`
+	BuyerAcceptsMarketing bool   `json:"buyer_accepts_marketing,omitempty"`
+	OrderStatusURL        string `json:"order_status_url,omitempty"`
+	CancelReason          string `json:"cancel_reason,omitempty"`
+
+	FinancialStatus   OrderFinancialStatus   `json:"financial_status,omitempty"`
+	FulfillmentStatus OrderFulfillmentStatus `json:"fulfillment_status,omitempty"`
+
+	// Totals
+	TaxesIncluded       bool   `json:"taxes_included,omitempty"`
+	SubtotalPrice       string `json:"subtotal_price,omitempty"`
+	TotalLineItemsPrice string `json:"total_line_items_price,omitempty"`
+	TotalDiscounts      string `json:"total_discounts,omitempty"`
+	TotalTax            string `json:"total_tax,omitempty"`
+	TotalPrice          string `json:"total_price,omitempty"`
+	TotalWeight         int    `json:"total_weight,omitempty"`
+	Currency            string `json:"currency,omitempty"`
+
+	LineItems            []*OrderLineItem       `json:"line_items,omitempty"`
+	ShippingLines        []*OrderShippingLine   `json:"shipping_lines,omitempty"`
+	TaxLines             []*TaxLine             `json:"tax_lines,omitempty"`
+	DiscountApplications []*DiscountApplication `json:"discount_applications,omitempty"`
+	DiscountCodes        []*OrderDiscountCode   `json:"discount_codes,omitempty"`
+
+	Customer        *Customer        `json:"customer,omitempty"`
+	BillingAddress  *CustomerAddress `json:"billing_address,omitempty"`
+	ShippingAddress *CustomerAddress `json:"shipping_address,omitempty"`
+
+	Fulfillments []*Fulfillment `json:"fulfillments,omitempty"`
+	Refunds      []*Refund      `json:"refunds,omitempty"`
+
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+	ProcessedAt *time.Time `json:"processed_at,omitempty"`
+	ClosedAt    *time.Time `json:"closed_at,omitempty"`
+	CancelledAt *time.Time `json:"cancelled_at,omitempty"`
+}
+
+type OrderLineItem struct {
+	ID                  int64  `json:"id"`
+	VariantID           int64  `json:"variant_id"`
+	ProductID           int64  `json:"product_id"`
+	Title               string `json:"title"`
+	VariantTitle        string `json:"variant_title,omitempty"`
+	Name                string `json:"name,omitempty"`
+	Vendor              string `json:"vendor,omitempty"`
+	Quantity            int    `json:"quantity"`
+	FulfillableQuantity int    `json:"fulfillable_quantity,omitempty"`
+	Price               string `json:"price"`
+	TotalDiscount       string `json:"total_discount,omitempty"`
+	Sku                 string `json:"sku"`
+	Grams               int    `json:"grams,omitempty"`
+
+	FulfillmentService string `json:"fulfillment_service,omitempty"`
+	FulfillmentStatus  string `json:"fulfillment_status,omitempty"`
+	RequiresShipping   bool   `json:"requires_shipping,omitempty"`
+	Taxable            bool   `json:"taxable,omitempty"`
+	GiftCard           bool   `json:"gift_card,omitempty"`
+
+	Properties          []*NoteAttribute      `json:"properties,omitempty"`
+	TaxLines            []*TaxLine            `json:"tax_lines,omitempty"`
+	DiscountAllocations []*DiscountAllocation `json:"discount_allocations,omitempty"`
+}
+
+type OrderShippingLine struct {
+	ID                int64  `json:"id,omitempty"`
+	Title             string `json:"title"`
+	Code              string `json:"code,omitempty"`
+	Source            string `json:"source,omitempty"`
+	Price             string `json:"price"`
+	DiscountedPrice   string `json:"discounted_price,omitempty"`
+	CarrierIdentifier string `json:"carrier_identifier,omitempty"`
+
+	TaxLines            []*TaxLine            `json:"tax_lines,omitempty"`
+	DiscountAllocations []*DiscountAllocation `json:"discount_allocations,omitempty"`
+}
+
+// DiscountApplication is a discount applied to the order, ie. by a discount
+// code or a script. Line items refer to it by index in their discount
+// allocations.
+type DiscountApplication struct {
+	Type             string `json:"type"`
+	Title            string `json:"title,omitempty"`
+	Description      string `json:"description,omitempty"`
+	Code             string `json:"code,omitempty"`
+	Value            string `json:"value"`
+	ValueType        string `json:"value_type"`
+	AllocationMethod string `json:"allocation_method"`
+	TargetSelection  string `json:"target_selection"`
+	TargetType       string `json:"target_type"`
+}
+
+type DiscountAllocation struct {
+	Amount                   string `json:"amount"`
+	DiscountApplicationIndex int    `json:"discount_application_index"`
+}
+
+type OrderDiscountCode struct {
+	Code   string `json:"code"`
+	Amount string `json:"amount"`
+	Type   string `json:"type"`
+}
+
+type NoteAttribute struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type OrderStatus string
+type OrderFinancialStatus string
+type OrderFulfillmentStatus string
+type OrderCancelReason string
+
+const (
+	OrderStatusOpen      OrderStatus = "open"
+	OrderStatusClosed    OrderStatus = "closed"
+	OrderStatusCancelled OrderStatus = "cancelled"
+	OrderStatusAny       OrderStatus = "any"
+
+	OrderFinancialStatusPending           OrderFinancialStatus = "pending"
+	OrderFinancialStatusAuthorized        OrderFinancialStatus = "authorized"
+	OrderFinancialStatusPartiallyPaid     OrderFinancialStatus = "partially_paid"
+	OrderFinancialStatusPaid              OrderFinancialStatus = "paid"
+	OrderFinancialStatusPartiallyRefunded OrderFinancialStatus = "partially_refunded"
+	OrderFinancialStatusRefunded          OrderFinancialStatus = "refunded"
+	OrderFinancialStatusVoided            OrderFinancialStatus = "voided"
+	OrderFinancialStatusUnpaid            OrderFinancialStatus = "unpaid" // filter only
+	OrderFinancialStatusAny               OrderFinancialStatus = "any"    // filter only
+
+	OrderFulfillmentStatusFulfilled   OrderFulfillmentStatus = "fulfilled"
+	OrderFulfillmentStatusPartial     OrderFulfillmentStatus = "partial"
+	OrderFulfillmentStatusRestocked   OrderFulfillmentStatus = "restocked"
+	OrderFulfillmentStatusShipped     OrderFulfillmentStatus = "shipped"     // filter only
+	OrderFulfillmentStatusUnshipped   OrderFulfillmentStatus = "unshipped"   // filter only
+	OrderFulfillmentStatusUnfulfilled OrderFulfillmentStatus = "unfulfilled" // filter only
+	OrderFulfillmentStatusAny         OrderFulfillmentStatus = "any"         // filter only
+
+	OrderCancelReasonCustomer  OrderCancelReason = "customer"
+	OrderCancelReasonFraud     OrderCancelReason = "fraud"
+	OrderCancelReasonInventory OrderCancelReason = "inventory"
+	OrderCancelReasonDeclined  OrderCancelReason = "declined"
+	OrderCancelReasonOther     OrderCancelReason = "other"
+)
+
+type OrderParam struct {
+	IDs               []int64
+	Limit             int
+	SinceID           int64
+	Status            OrderStatus
+	FinancialStatus   OrderFinancialStatus
+	FulfillmentStatus OrderFulfillmentStatus
+	CreatedAtMin      *time.Time
+	CreatedAtMax      *time.Time
+	UpdatedAtMin      *time.Time
+	UpdatedAtMax      *time.Time
+	ProcessedAtMin    *time.Time
+	ProcessedAtMax    *time.Time
+	Fields            []string
+	PageInfo          string
+}
+
+func (p *OrderParam) EncodeQuery() string {
+	if p == nil {
+		return ""
+	}
+	v := url.Values{}
+	if p.Limit > 0 {
+		v.Add("limit", fmt.Sprintf("%d", p.Limit))
+	}
+	if len(p.Fields) > 0 {
+		v.Add("fields", strings.Join(p.Fields, ","))
+	}
+	if len(p.IDs) > 0 {
+		v.Add("ids", joinIDs(p.IDs))
+	}
+	if p.SinceID > 0 {
+		v.Add("since_id", fmt.Sprintf("%d", p.SinceID))
+	}
+	if p.Status != "" {
+		v.Add("status", string(p.Status))
+	}
+	if p.FinancialStatus != "" {
+		v.Add("financial_status", string(p.FinancialStatus))
+	}
+	if p.FulfillmentStatus != "" {
+		v.Add("fulfillment_status", string(p.FulfillmentStatus))
+	}
+	if p.CreatedAtMin != nil {
+		v.Add("created_at_min", p.CreatedAtMin.Format(timeFormat))
+	}
+	if p.CreatedAtMax != nil {
+		v.Add("created_at_max", p.CreatedAtMax.Format(timeFormat))
+	}
+	if p.UpdatedAtMin != nil {
+		v.Add("updated_at_min", p.UpdatedAtMin.Format(timeFormat))
+	}
+	if p.UpdatedAtMax != nil {
+		v.Add("updated_at_max", p.UpdatedAtMax.Format(timeFormat))
+	}
+	if p.ProcessedAtMin != nil {
+		v.Add("processed_at_min", p.ProcessedAtMin.Format(timeFormat))
+	}
+	if p.ProcessedAtMax != nil {
+		v.Add("processed_at_max", p.ProcessedAtMax.Format(timeFormat))
+	}
+	return encodeQuery(v, p.PageInfo)
+}
+
+// OrderCancelParam are the options of an order cancellation.
+type OrderCancelParam struct {
+	// Amount to refund, defaults to the full amount
+	Amount   string `json:"amount,omitempty"`
+	Currency string `json:"currency,omitempty"`
+	// Restock the items of the order
+	Restock bool              `json:"restock,omitempty"`
+	Reason  OrderCancelReason `json:"reason,omitempty"`
+	// Email the customer about the cancellation
+	Email bool `json:"email"`
+	// Refund to issue, for finer control than Amount and Restock
+	Refund *Refund `json:"refund,omitempty"`
+}
+
+type OrderRequest struct {
+	Order *Order `json:"order"`
+}
+
+func (s *OrderService) List(ctx context.Context, params *OrderParam) ([]*Order, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "/admin/orders.json", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	// encode param to query
+	req.URL.RawQuery = params.EncodeQuery()
+
+	var orderWrapper struct {
+		Orders []*Order `json:"orders"`
+	}
+	resp, err := s.client.Do(ctx, req, &orderWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return orderWrapper.Orders, resp, nil
+}
+
+// OrderIterator iterates over orders, see Pagination.
+type OrderIterator struct{ iterator }
+
+// Value returns the current order.
+func (it *OrderIterator) Value() *Order {
+	v, _ := it.value().(*Order)
+	return v
+}
+
+// Iter returns an iterator over every order matching params.
+func (s *OrderService) Iter(params *OrderParam) *OrderIterator {
+	var pp OrderParam
+	if params != nil {
+		pp = *params
+	}
+	return &OrderIterator{newIterator(func(ctx context.Context, pageInfo string) (interface{}, *http.Response, error) {
+		pp.PageInfo = pageInfo
+		return s.List(ctx, &pp)
+	})}
+}
+
+func (s *OrderService) Count(ctx context.Context, params *OrderParam) (int, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "/admin/orders/count.json", nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	// limit, fields and cursors don't apply to counts
+	var pp OrderParam
+	if params != nil {
+		pp = *params
+		pp.Limit, pp.Fields, pp.PageInfo = 0, nil, ""
+	}
+	req.URL.RawQuery = pp.EncodeQuery()
+
+	var orderCount struct {
+		Count int `json:"count"`
+	}
+	resp, err := s.client.Do(ctx, req, &orderCount)
+	if err != nil {
+		return 0, resp, err
+	}
+
+	return orderCount.Count, resp, nil
+}
+
+func (s *OrderService) Get(ctx context.Context, ID int64) (*Order, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("/admin/orders/%d.json", ID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	orderWrapper := new(OrderRequest)
+	resp, err := s.client.Do(ctx, req, orderWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return orderWrapper.Order, resp, nil
+}
+
+// OrderUpdate is a partial update of an order, holding the fields Shopify
+// allows to change. Only the fields that are set are sent, see VariantUpdate:
+//
+//	client.Order.Update(ctx, &shopify.OrderUpdate{
+//		ID:   orderID,
+//		Note: &shopify.NullString{}, // clears the note
+//		Tags: shopify.String("gift"),
+//	})
+type OrderUpdate struct {
+	ID                    int64             `json:"id"`
+	Email                 *NullString       `json:"email,omitempty"`
+	Phone                 *NullString       `json:"phone,omitempty"`
+	Note                  *NullString       `json:"note,omitempty"`
+	NoteAttributes        *[]*NoteAttribute `json:"note_attributes,omitempty"`
+	Tags                  *string           `json:"tags,omitempty"`
+	BuyerAcceptsMarketing *bool             `json:"buyer_accepts_marketing,omitempty"`
+	// ShippingAddress replaces the shipping address of the order
+	ShippingAddress *CustomerAddress `json:"shipping_address,omitempty"`
+}
+
+// Update updates the order with the fields that are set, ie. the note, tags,
+// email or shipping address.
+func (s *OrderService) Update(ctx context.Context, order *OrderUpdate) (*Order, *http.Response, error) {
+	body := struct {
+		Order *OrderUpdate `json:"order"`
+	}{order}
+	req, err := s.client.NewRequest("PUT", fmt.Sprintf("/admin/orders/%d.json", order.ID), &body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	orderWrapper := new(OrderRequest)
+	resp, err := s.client.Do(ctx, req, orderWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return orderWrapper.Order, resp, nil
+}
+
+func (s *OrderService) Delete(ctx context.Context, ID int64) (*http.Response, error) {
+	req, err := s.client.NewRequest("DELETE", fmt.Sprintf("/admin/orders/%d.json", ID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(ctx, req, nil)
+}
+
+func (s *OrderService) Close(ctx context.Context, ID int64) (*Order, *http.Response, error) {
+	return s.post(ctx, fmt.Sprintf("/admin/orders/%d/close.json", ID), struct{}{})
+}
+
+func (s *OrderService) Open(ctx context.Context, ID int64) (*Order, *http.Response, error) {
+	return s.post(ctx, fmt.Sprintf("/admin/orders/%d/open.json", ID), struct{}{})
+}
+
+// Cancel cancels the order. A nil params cancels the order without refund,
+// restock or notification.
+func (s *OrderService) Cancel(ctx context.Context, ID int64, params *OrderCancelParam) (*Order, *http.Response, error) {
+	if params == nil {
+		params = &OrderCancelParam{}
+	}
+	return s.post(ctx, fmt.Sprintf("/admin/orders/%d/cancel.json", ID), params)
+}
+
+// post sends an order action and returns the resulting order.
+func (s *OrderService) post(ctx context.Context, path string, body interface{}) (*Order, *http.Response, error) {
+	req, err := s.client.NewRequest("POST", path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	orderWrapper := new(OrderRequest)
+	resp, err := s.client.Do(ctx, req, orderWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return orderWrapper.Order, resp, nil
+}
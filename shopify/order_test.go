@@ -0,0 +1,142 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOrderParam(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2019, 3, 29, 13, 2, 8, 0, time.UTC)
+	inputs := []struct {
+		name     string
+		params   *OrderParam
+		expected string
+	}{
+		{"nil", nil, ""},
+		{
+			"filters",
+			&OrderParam{
+				IDs:               []int64{1, 2},
+				SinceID:           3,
+				Status:            OrderStatusAny,
+				FinancialStatus:   OrderFinancialStatusPaid,
+				FulfillmentStatus: OrderFulfillmentStatusShipped,
+				ProcessedAtMin:    &since,
+				Fields:            []string{"id", "name"},
+				Limit:             50,
+			},
+			"fields=id%2Cname&financial_status=paid&fulfillment_status=shipped&ids=1%2C2&limit=50" +
+				"&processed_at_min=2019-03-29T13%3A02%3A08%2B00%3A00&since_id=3&status=any",
+		},
+		{
+			"cursor",
+			&OrderParam{Status: OrderStatusAny, Limit: 50, PageInfo: "abc"},
+			"limit=50&page_info=abc",
+		},
+	}
+
+	for _, tt := range inputs {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if q := tt.params.EncodeQuery(); q != tt.expected {
+				t.Errorf("expected %q got %q", tt.expected, q)
+			}
+		})
+	}
+}
+
+func TestOrderList(t *testing.T) {
+	t.Parallel()
+
+	c, last, teardown := setup(http.StatusOK, `{"orders":[{"id":1,"name":"#1001","financial_status":"paid"},{"id":2}]}`)
+	defer teardown()
+
+	orders, _, err := c.Order.List(context.Background(), &OrderParam{Status: OrderStatusAny, Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if r := last(); r.Path != "/admin/orders.json" || r.Query != "limit=2&status=any" {
+		t.Errorf("unexpected request %s?%s", r.Path, r.Query)
+	}
+	if len(orders) != 2 || orders[0].Name != "#1001" || orders[0].FinancialStatus != OrderFinancialStatusPaid {
+		t.Errorf("unexpected orders %+v", orders)
+	}
+}
+
+func TestOrderGet(t *testing.T) {
+	t.Parallel()
+
+	c, last, teardown := setup(http.StatusOK, `{"order":{"id":1,"line_items":[{"id":2,"quantity":3}]}}`)
+	defer teardown()
+
+	order, _, err := c.Order.Get(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if r := last(); r.Method != "GET" || r.Path != "/admin/orders/1.json" {
+		t.Errorf("unexpected request %s %s", r.Method, r.Path)
+	}
+	if order.ID != 1 || len(order.LineItems) != 1 || order.LineItems[0].Quantity != 3 {
+		t.Errorf("unexpected order %+v", order)
+	}
+}
+
+func TestOrderCount(t *testing.T) {
+	t.Parallel()
+
+	c, last, teardown := setup(http.StatusOK, `{"count":3}`)
+	defer teardown()
+
+	// limit, fields and cursors are left out of counts
+	params := &OrderParam{Status: OrderStatusOpen, Limit: 50, Fields: []string{"id"}, PageInfo: "abc"}
+	count, _, err := c.Order.Count(context.Background(), params)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if r := last(); r.Path != "/admin/orders/count.json" || r.Query != "status=open" {
+		t.Errorf("unexpected request %s?%s", r.Path, r.Query)
+	}
+	if count != 3 {
+		t.Errorf("expected a count of 3, got %d", count)
+	}
+}
+
+func TestOrderUpdate(t *testing.T) {
+	t.Parallel()
+
+	c, last, teardown := setup(http.StatusOK, `{"order":{"id":1,"tags":"gift","buyer_accepts_marketing":false}}`)
+	defer teardown()
+
+	order, _, err := c.Order.Update(context.Background(), &OrderUpdate{
+		ID:                    1,
+		Note:                  &NullString{},
+		Tags:                  String("gift"),
+		BuyerAcceptsMarketing: Bool(false),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	r := last()
+	if r.Method != "PUT" || r.Path != "/admin/orders/1.json" {
+		t.Errorf("unexpected request %s %s", r.Method, r.Path)
+	}
+	// only the fields that are set are sent, line items and totals left out
+	expected := `{"order":{"id":1,"note":null,"tags":"gift","buyer_accepts_marketing":false}}`
+	if body := strings.TrimSpace(r.Body); body != expected {
+		t.Errorf("expected body %s got %s", expected, body)
+	}
+	if order.Tags != "gift" {
+		t.Errorf("unexpected order %+v", order)
+	}
+}
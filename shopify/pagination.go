@@ -0,0 +1,166 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// Pagination holds the cursors of the pages around a list response. Newer API
+// versions paginate with cursors passed back in the Link header, ie.
+//
+//	Link: <https://x.myshopify.com/admin/api/2019-07/products.json?page_info=abc&limit=50>; rel="next"
+//
+// List params take the cursor of the page to fetch in PageInfo, in place of
+// the Page numbers older API versions used. Iter methods follow the cursors
+// through every page.
+//
+// Shopify API docs: https://help.shopify.com/en/api/guides/paginated-rest-results
+type Pagination struct {
+	NextPageInfo     string
+	PreviousPageInfo string
+}
+
+// HasNext reports whether there is a page after the current one.
+func (p *Pagination) HasNext() bool {
+	return p != nil && p.NextPageInfo != ""
+}
+
+// HasPrevious reports whether there is a page before the current one.
+func (p *Pagination) HasPrevious() bool {
+	return p != nil && p.PreviousPageInfo != ""
+}
+
+// ParsePagination parses the page_info cursors out of the Link header of a
+// list response. It returns an empty Pagination if there is no other page.
+func ParsePagination(r *http.Response) *Pagination {
+	p := new(Pagination)
+	if r == nil {
+		return p
+	}
+	for _, h := range r.Header["Link"] {
+		for _, link := range strings.Split(h, ",") {
+			segments := strings.Split(link, ";")
+			if len(segments) < 2 {
+				continue
+			}
+			target := strings.TrimSpace(segments[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+			u, err := url.Parse(target[1 : len(target)-1])
+			if err != nil {
+				continue
+			}
+			pageInfo := u.Query().Get("page_info")
+
+			for _, segment := range segments[1:] {
+				switch strings.TrimSpace(segment) {
+				case `rel="next"`:
+					p.NextPageInfo = pageInfo
+				case `rel="previous"`:
+					p.PreviousPageInfo = pageInfo
+				}
+			}
+		}
+	}
+	return p
+}
+
+// encodeQuery encodes the query of a list request. Fetching a page by its
+// cursor, Shopify rejects every filter but limit and fields: the cursor
+// carries the filters of the first page.
+func encodeQuery(v url.Values, pageInfo string) string {
+	if pageInfo != "" {
+		for k := range v {
+			if k != "limit" && k != "fields" {
+				v.Del(k)
+			}
+		}
+		v.Set("page_info", pageInfo)
+	}
+	return v.Encode()
+}
+
+// iterator walks every item of a paginated list, fetching pages as it goes.
+// It is embedded by the typed iterators of each resource, ie.
+//
+//	it := client.Product.Iter(&shopify.ProductParam{Limit: 250})
+//	for it.Next(ctx) {
+//	  product := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//	  ...
+//	}
+type iterator struct {
+	fetch pageFetcher
+
+	items    reflect.Value
+	pos      int
+	pageInfo string
+	last     bool
+
+	resp *http.Response
+	err  error
+}
+
+// pageFetcher fetches the page at the given cursor, returning a slice of its
+// items. An empty pageInfo fetches the first page.
+type pageFetcher func(ctx context.Context, pageInfo string) (interface{}, *http.Response, error)
+
+func newIterator(fetch pageFetcher) iterator {
+	return iterator{fetch: fetch}
+}
+
+// Next advances the iterator to the next item, fetching the next page if
+// needed. It returns false when there are no more items or an error occurred.
+func (it *iterator) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	for !it.items.IsValid() || it.pos >= it.items.Len() {
+		if it.last {
+			return false
+		}
+
+		items, resp, err := it.fetch(ctx, it.pageInfo)
+		it.resp = resp
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.items = reflect.ValueOf(items)
+		it.pos = 0
+
+		p := ParsePagination(resp)
+		it.pageInfo = p.NextPageInfo
+		it.last = !p.HasNext()
+	}
+	it.pos++
+	return true
+}
+
+// value returns the current item, nil before the first call to Next.
+func (it *iterator) value() interface{} {
+	if it.pos == 0 || !it.items.IsValid() || it.pos > it.items.Len() {
+		return nil
+	}
+	return it.items.Index(it.pos - 1).Interface()
+}
+
+// Err returns the error, if any, that stopped the iteration.
+func (it *iterator) Err() error {
+	return it.err
+}
+
+// Response returns the response of the last page fetched.
+func (it *iterator) Response() *http.Response {
+	return it.resp
+}
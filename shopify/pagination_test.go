@@ -0,0 +1,114 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParsePagination(t *testing.T) {
+	t.Parallel()
+
+	inputs := []struct {
+		name     string
+		link     string
+		expected Pagination
+	}{
+		{
+			name:     "no link",
+			link:     "",
+			expected: Pagination{},
+		},
+		{
+			name:     "next only",
+			link:     `<https://x.myshopify.com/admin/api/2019-07/products.json?limit=50&page_info=abc>; rel="next"`,
+			expected: Pagination{NextPageInfo: "abc"},
+		},
+		{
+			name:     "next and previous",
+			link:     `<https://x.myshopify.com/admin/api/2019-07/products.json?page_info=prev&limit=50>; rel="previous", <https://x.myshopify.com/admin/api/2019-07/products.json?page_info=next&limit=50>; rel="next"`,
+			expected: Pagination{NextPageInfo: "next", PreviousPageInfo: "prev"},
+		},
+	}
+
+	for _, tt := range inputs {
+		r := &http.Response{Header: http.Header{}}
+		if tt.link != "" {
+			r.Header.Set("Link", tt.link)
+		}
+		if actual := ParsePagination(r); *actual != tt.expected {
+			t.Errorf("%s: expected %+v got %+v", tt.name, tt.expected, *actual)
+		}
+	}
+}
+
+func TestEncodeCursor(t *testing.T) {
+	t.Parallel()
+
+	inputs := []struct {
+		name     string
+		params   interface{ EncodeQuery() string }
+		expected string
+	}{
+		{
+			name:     "filters",
+			params:   &OrderParam{IDs: []int64{1, 2}, Status: OrderStatusAny, Limit: 50},
+			expected: "ids=1%2C2&limit=50&status=any",
+		},
+		{
+			name:     "cursor drops filters",
+			params:   &OrderParam{IDs: []int64{1, 2}, Status: OrderStatusAny, Limit: 50, Fields: []string{"id"}, PageInfo: "abc"},
+			expected: "fields=id&limit=50&page_info=abc",
+		},
+		{
+			name:     "cursor drops page",
+			params:   &VariantParam{Page: 2, Limit: 50, PageInfo: "abc"},
+			expected: "limit=50&page_info=abc",
+		},
+	}
+
+	for _, tt := range inputs {
+		if q := tt.params.EncodeQuery(); q != tt.expected {
+			t.Errorf("%s: expected %q got %q", tt.name, tt.expected, q)
+		}
+	}
+}
+
+func TestIterator(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page_info") {
+		case "":
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/admin/products.json?page_info=p2>; rel="next"`, r.Host))
+			w.Write([]byte(`{"products":[{"id":1},{"id":2}]}`))
+		case "p2":
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/admin/products.json?page_info=p1>; rel="previous"`, r.Host))
+			w.Write([]byte(`{"products":[{"id":3}]}`))
+		default:
+			t.Errorf("unexpected page %s", r.URL)
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient(nil, ShopURL(ts.URL))
+	it := c.Product.Iter(&ProductParam{Limit: 2})
+
+	var ids []int64
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Value().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 3 || ids[0] != 1 || ids[2] != 3 {
+		t.Errorf("expected products 1 to 3 got %v", ids)
+	}
+}
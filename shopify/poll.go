@@ -0,0 +1,110 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Poller polls for the result of a request Shopify processes in the
+// background. Such requests are answered with 202 Accepted, the Location to
+// poll and how long to wait before polling in Retry-After.
+type Poller struct {
+	// MinDelay is the shortest wait between polls, also used when
+	// Retry-After is missing.
+	MinDelay time.Duration
+	// MaxDelay caps the wait between polls.
+	MaxDelay time.Duration
+	// MaxAttempts caps the number of polls, 0 for no limit.
+	MaxAttempts int
+	// MaxDuration caps the time spent polling, 0 for no limit.
+	MaxDuration time.Duration
+}
+
+// DefaultPoller polls every 0.5 to 10s, for up to 30 polls or 2 minutes.
+var DefaultPoller = &Poller{
+	MinDelay:    500 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+	MaxAttempts: 30,
+	MaxDuration: 2 * time.Minute,
+}
+
+var ErrPollTimeout = errors.New("gave up polling for the result")
+
+// Poll is an Option to set how the client polls for background results. A
+// nil poller restores DefaultPoller.
+func Poll(p *Poller) Option {
+	return func(o *Options) error {
+		if p == nil {
+			p = DefaultPoller
+		}
+		o.poller = p
+		return nil
+	}
+}
+
+// delay returns how long to wait before polling again after resp.
+func (p *Poller) delay(resp *http.Response) time.Duration {
+	wait, _ := retryAfter(resp)
+	if wait < p.MinDelay {
+		wait = p.MinDelay
+	}
+	if p.MaxDelay > 0 && wait > p.MaxDelay {
+		wait = p.MaxDelay
+	}
+	return wait
+}
+
+// poll follows resp while it is a 202 Accepted, until the result is ready
+// and decoded into v. It returns the last response.
+func (c *Client) poll(ctx context.Context, resp *http.Response, v interface{}) (*http.Response, error) {
+	p := c.opts.poller
+	var deadline time.Time
+	if p.MaxDuration > 0 {
+		deadline = time.Now().Add(p.MaxDuration)
+	}
+
+	for attempt := 1; resp.StatusCode == http.StatusAccepted; attempt++ {
+		if p.MaxAttempts > 0 && attempt > p.MaxAttempts {
+			return resp, fmt.Errorf("%w: after %d attempts", ErrPollTimeout, p.MaxAttempts)
+		}
+		wait := p.delay(resp)
+		if !deadline.IsZero() && time.Now().Add(wait).After(deadline) {
+			return resp, fmt.Errorf("%w: after %s", ErrPollTimeout, p.MaxDuration)
+		}
+
+		location := resp.Header.Get("Location")
+		if location == "" {
+			// without a Location, only a GET can be polled again as is
+			if resp.Request == nil || resp.Request.Method != "GET" {
+				return resp, errors.New("accepted response has no Location to poll")
+			}
+			location = resp.Request.URL.String()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, ctx.Err()
+		case <-timer.C:
+		}
+
+		req, err := c.NewRequest("GET", location, nil)
+		if err != nil {
+			return resp, err
+		}
+		resp, err = c.Do(ctx, req, v)
+		if err != nil {
+			return resp, err
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPoll(t *testing.T) {
+	t.Parallel()
+
+	inputs := []struct {
+		name     string
+		poller   *Poller
+		ready    int // poll at which the result is ready
+		timeout  time.Duration
+		expected error
+		polls    int // -1 to skip, for timing dependent cases
+	}{
+		{
+			name:   "ready",
+			poller: &Poller{MinDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond},
+			ready:  3,
+			polls:  3,
+		},
+		{
+			name:     "max attempts",
+			poller:   &Poller{MinDelay: time.Millisecond, MaxAttempts: 2},
+			ready:    5,
+			expected: ErrPollTimeout,
+			polls:    2,
+		},
+		{
+			name:     "max duration",
+			poller:   &Poller{MinDelay: 20 * time.Millisecond, MaxDuration: 50 * time.Millisecond},
+			ready:    10,
+			expected: ErrPollTimeout,
+			polls:    -1,
+		},
+		{
+			name:     "context",
+			poller:   &Poller{MinDelay: time.Hour},
+			ready:    2,
+			timeout:  10 * time.Millisecond,
+			expected: context.DeadlineExceeded,
+			polls:    0,
+		},
+	}
+
+	for _, tt := range inputs {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var polls int
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == "GET" {
+					polls++
+				}
+				if r.Method == "POST" || polls < tt.ready {
+					// no Retry-After, the poller's MinDelay applies
+					w.Header().Set("Location", "/admin/checkouts/abc.json")
+					w.WriteHeader(http.StatusAccepted)
+					return
+				}
+				w.Write([]byte(`{"checkout":{"token":"abc","order_id":1}}`))
+			}))
+			defer ts.Close()
+
+			c, _ := NewClient(nil, ShopURL(ts.URL), Poll(tt.poller))
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			checkout, _, err := c.Checkout.Complete(ctx, "abc")
+			if tt.expected != nil {
+				if !errors.Is(err, tt.expected) {
+					t.Errorf("expected %v, got %v", tt.expected, err)
+				}
+			} else if err != nil || checkout.OrderID != 1 {
+				t.Errorf("expected the completed checkout, got %+v %v", checkout, err)
+			}
+			if tt.polls >= 0 && polls != tt.polls {
+				t.Errorf("expected %d polls, got %d", tt.polls, polls)
+			}
+		})
+	}
+}
@@ -78,8 +78,9 @@ const (
 )
 
 type PriceRuleParam struct {
-	Limit int `json:"limit"`
-	Page  int `json:"page"`
+	Limit    int    `json:"limit"`
+	Page     int    `json:"page"`
+	PageInfo string `json:"page_info"`
 	// Show rule starting AFTER date
 	StartsAtMin *time.Time `json:"starts_at_min"`
 	// Show rule starting BEFORE date
@@ -123,7 +124,7 @@ func (p *PriceRuleParam) EncodeQuery() string {
 	if p.StartsAtMax != nil {
 		v.Add("starts_at_max", p.StartsAtMax.Format(timeFormat))
 	}
-	return v.Encode()
+	return encodeQuery(v, p.PageInfo)
 }
 
 func (p *PriceRuleService) List(ctx context.Context, params *PriceRuleParam) ([]*PriceRule, *http.Response, error) {
@@ -144,6 +145,27 @@ func (p *PriceRuleService) List(ctx context.Context, params *PriceRuleParam) ([]
 	return priceRuleWrapper.PriceRules, resp, nil
 }
 
+// PriceRuleIterator iterates over price rules, see Pagination.
+type PriceRuleIterator struct{ iterator }
+
+// Value returns the current price rule.
+func (it *PriceRuleIterator) Value() *PriceRule {
+	v, _ := it.value().(*PriceRule)
+	return v
+}
+
+// Iter returns an iterator over every price rule matching params.
+func (p *PriceRuleService) Iter(params *PriceRuleParam) *PriceRuleIterator {
+	var pp PriceRuleParam
+	if params != nil {
+		pp = *params
+	}
+	return &PriceRuleIterator{newIterator(func(ctx context.Context, pageInfo string) (interface{}, *http.Response, error) {
+		pp.PageInfo = pageInfo
+		return p.List(ctx, &pp)
+	})}
+}
+
 func (p *PriceRuleService) Get(ctx context.Context, ID int64) (*PriceRule, *http.Response, error) {
 	req, err := p.client.NewRequest("GET", fmt.Sprintf("/admin/price_rules/%d.json", ID), nil)
 	if err != nil {
@@ -9,22 +9,26 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 )
 
 type ProductService service
 
 type Product struct {
-	ProductID      int64       `json:"product_id"`
-	Title          string      `json:"title"`
-	BodyHTML       string      `json:"body_html"`
-	Vendor         string      `json:"vendor"`
-	ProductType    string      `json:"product_type"`
-	Handle         string      `json:"handle"`
-	TemplateSuffix interface{} `json:"template_suffix"`
-	PublishedScope string      `json:"published_scope"`
-	Tags           string      `json:"tags"`
-	Available      bool        `json:"available"`
+	ID             int64         `json:"id"`
+	ProductID      int64         `json:"product_id"`
+	Title          string        `json:"title"`
+	BodyHTML       string        `json:"body_html"`
+	Vendor         string        `json:"vendor"`
+	ProductType    string        `json:"product_type"`
+	Handle         string        `json:"handle"`
+	Status         ProductStatus `json:"status,omitempty"`
+	TemplateSuffix interface{}   `json:"template_suffix"`
+	PublishedScope string        `json:"published_scope"`
+	Tags           string        `json:"tags"`
+	Available      bool          `json:"available"`
 
 	Variants []*ProductVariant `json:"variants"`
 	Options  []*ProductOption  `json:"options"`
@@ -36,6 +40,22 @@ type Product struct {
 	PublishedAt time.Time `json:"published_at"`
 }
 
+type ProductStatus string
+
+const (
+	ProductStatusActive   ProductStatus = "active"
+	ProductStatusArchived ProductStatus = "archived"
+	ProductStatusDraft    ProductStatus = "draft"
+)
+
+type ProductPublishedStatus string
+
+const (
+	ProductPublishedStatusPublished   ProductPublishedStatus = "published"
+	ProductPublishedStatusUnpublished ProductPublishedStatus = "unpublished"
+	ProductPublishedStatusAny         ProductPublishedStatus = "any"
+)
+
 type ProductVariant struct {
 	ID                   int64           `json:"id"`
 	ProductID            int64           `json:"product_id"`
@@ -56,6 +76,7 @@ type ProductVariant struct {
 	ImageID              interface{}     `json:"image_id"`
 	CompareAtPrice       string          `json:"compare_at_price"`
 	Available            bool            `json:"available"`
+	InventoryItemID      int64           `json:"inventory_item_id"`
 	InventoryQuantity    int             `json:"inventory_quantity"`
 	Weight               float64         `json:"weight"`
 	WeightUnit           string          `json:"weight_unit"`
@@ -84,6 +105,7 @@ type ProductImage struct {
 	ID         int64   `json:"id"`
 	ProductID  int64   `json:"product_id"`
 	Position   int     `json:"position"`
+	Alt        string  `json:"alt"`
 	CreatedAt  string  `json:"created_at"`
 	UpdatedAt  string  `json:"updated_at"`
 	Width      int64   `json:"width"`
@@ -92,11 +114,132 @@ type ProductImage struct {
 	VariantIds []int64 `json:"variant_ids"`
 }
 
+type ProductParam struct {
+	IDs             []int64
+	Limit           int
+	SinceID         int64
+	Title           string
+	Vendor          string
+	Handle          string
+	ProductType     string
+	CollectionID    int64
+	Status          ProductStatus
+	PublishedStatus ProductPublishedStatus
+	CreatedAtMin    *time.Time
+	CreatedAtMax    *time.Time
+	UpdatedAtMin    *time.Time
+	UpdatedAtMax    *time.Time
+	PublishedAtMin  *time.Time
+	PublishedAtMax  *time.Time
+	Fields          []string
+	PageInfo        string
+}
+
+type ProductRequest struct {
+	Product *Product `json:"product"`
+}
+
+// ProductUpdate is a product to create, or a partial update of one. Only the
+// fields that are set are sent, see VariantUpdate. Variants, options and
+// images that are set replace the existing ones.
+type ProductUpdate struct {
+	ID             int64          `json:"id,omitempty"`
+	Title          *string        `json:"title,omitempty"`
+	BodyHTML       *string        `json:"body_html,omitempty"`
+	Vendor         *string        `json:"vendor,omitempty"`
+	ProductType    *string        `json:"product_type,omitempty"`
+	Handle         *string        `json:"handle,omitempty"`
+	Status         *ProductStatus `json:"status,omitempty"`
+	TemplateSuffix *NullString    `json:"template_suffix,omitempty"`
+	PublishedScope *string        `json:"published_scope,omitempty"`
+	Tags           *string        `json:"tags,omitempty"`
+	// Published publishes or hides the product, when set
+	Published *bool `json:"published,omitempty"`
+
+	Variants []*VariantUpdate       `json:"variants,omitempty"`
+	Options  []*ProductOptionUpdate `json:"options,omitempty"`
+	Images   []*ProductImageUpdate  `json:"images,omitempty"`
+}
+
+// ProductOptionUpdate is an option of a ProductUpdate, ie. its size or color.
+type ProductOptionUpdate struct {
+	ID       int64     `json:"id,omitempty"`
+	Name     *string   `json:"name,omitempty"`
+	Position *int      `json:"position,omitempty"`
+	Values   *[]string `json:"values,omitempty"`
+}
+
+func (p *ProductParam) EncodeQuery() string {
+	if p == nil {
+		return ""
+	}
+	v := url.Values{}
+	if p.Limit > 0 {
+		v.Add("limit", fmt.Sprintf("%d", p.Limit))
+	}
+	if len(p.Fields) > 0 {
+		v.Add("fields", strings.Join(p.Fields, ","))
+	}
+	if len(p.IDs) > 0 {
+		v.Add("ids", joinIDs(p.IDs))
+	}
+	if p.SinceID > 0 {
+		v.Add("since_id", fmt.Sprintf("%d", p.SinceID))
+	}
+	if p.Title != "" {
+		v.Add("title", p.Title)
+	}
+	if p.Vendor != "" {
+		v.Add("vendor", p.Vendor)
+	}
+	if p.Handle != "" {
+		v.Add("handle", p.Handle)
+	}
+	if p.ProductType != "" {
+		v.Add("product_type", p.ProductType)
+	}
+	if p.CollectionID > 0 {
+		v.Add("collection_id", fmt.Sprintf("%d", p.CollectionID))
+	}
+	if p.Status != "" {
+		v.Add("status", string(p.Status))
+	}
+	if p.PublishedStatus != "" {
+		v.Add("published_status", string(p.PublishedStatus))
+	}
+	if p.CreatedAtMin != nil {
+		v.Add("created_at_min", p.CreatedAtMin.Format(timeFormat))
+	}
+	if p.CreatedAtMax != nil {
+		v.Add("created_at_max", p.CreatedAtMax.Format(timeFormat))
+	}
+	if p.UpdatedAtMin != nil {
+		v.Add("updated_at_min", p.UpdatedAtMin.Format(timeFormat))
+	}
+	if p.UpdatedAtMax != nil {
+		v.Add("updated_at_max", p.UpdatedAtMax.Format(timeFormat))
+	}
+	if p.PublishedAtMin != nil {
+		v.Add("published_at_min", p.PublishedAtMin.Format(timeFormat))
+	}
+	if p.PublishedAtMax != nil {
+		v.Add("published_at_max", p.PublishedAtMax.Format(timeFormat))
+	}
+	return encodeQuery(v, p.PageInfo)
+}
+
 func (p *ProductService) List(ctx context.Context) ([]*Product, *http.Response, error) {
+	return p.ListWithParams(ctx, nil)
+}
+
+// ListWithParams returns the products matching params.
+func (p *ProductService) ListWithParams(ctx context.Context, params *ProductParam) ([]*Product, *http.Response, error) {
 	req, err := p.client.NewRequest("GET", "/admin/products.json", nil)
 	if err != nil {
 		return nil, nil, err
 	}
+	// encode param to query
+	req.URL.RawQuery = params.EncodeQuery()
 
 	var productWrapper struct {
 		Products []*Product `json:"products"`
@@ -109,6 +252,113 @@ func (p *ProductService) List(ctx context.Context) ([]*Product, *http.Response,
 	return productWrapper.Products, resp, nil
 }
 
+// ProductIterator iterates over products, see Pagination.
+type ProductIterator struct{ iterator }
+
+// Value returns the current product.
+func (it *ProductIterator) Value() *Product {
+	v, _ := it.value().(*Product)
+	return v
+}
+
+// Iter returns an iterator over every product matching params.
+func (p *ProductService) Iter(params *ProductParam) *ProductIterator {
+	var pp ProductParam
+	if params != nil {
+		pp = *params
+	}
+	return &ProductIterator{newIterator(func(ctx context.Context, pageInfo string) (interface{}, *http.Response, error) {
+		pp.PageInfo = pageInfo
+		return p.ListWithParams(ctx, &pp)
+	})}
+}
+
+func (p *ProductService) Count(ctx context.Context, params *ProductParam) (int, *http.Response, error) {
+	req, err := p.client.NewRequest("GET", "/admin/products/count.json", nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	// limit, fields and cursors don't apply to counts
+	var pp ProductParam
+	if params != nil {
+		pp = *params
+		pp.Limit, pp.Fields, pp.PageInfo = 0, nil, ""
+	}
+	req.URL.RawQuery = pp.EncodeQuery()
+
+	var productCount struct {
+		Count int `json:"count"`
+	}
+	resp, err := p.client.Do(ctx, req, &productCount)
+	if err != nil {
+		return 0, resp, err
+	}
+
+	return productCount.Count, resp, nil
+}
+
+func (p *ProductService) Get(ctx context.Context, ID int64) (*Product, *http.Response, error) {
+	req, err := p.client.NewRequest("GET", fmt.Sprintf("/admin/products/%d.json", ID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	productWrapper := new(ProductRequest)
+	resp, err := p.client.Do(ctx, req, productWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return productWrapper.Product, resp, nil
+}
+
+// Create creates a product, along with its variants, options and images if
+// any. Products are published by default, set Published to false to create a
+// hidden product:
+//
+//	client.Product.Create(ctx, &shopify.ProductUpdate{
+//		Title:     shopify.String("Example T-Shirt"),
+//		Published: shopify.Bool(false),
+//		Variants:  []*shopify.VariantUpdate{{Price: shopify.String("19.99")}},
+//	})
+func (p *ProductService) Create(ctx context.Context, product *ProductUpdate) (*Product, *http.Response, error) {
+	return p.save(ctx, "POST", "/admin/products.json", product)
+}
+
+// Update updates the product with the fields that are set. Variants and
+// images that are set replace the existing ones, variants left out are
+// deleted.
+func (p *ProductService) Update(ctx context.Context, product *ProductUpdate) (*Product, *http.Response, error) {
+	return p.save(ctx, "PUT", fmt.Sprintf("/admin/products/%d.json", product.ID), product)
+}
+
+func (p *ProductService) save(ctx context.Context, method, path string, product *ProductUpdate) (*Product, *http.Response, error) {
+	body := struct {
+		Product *ProductUpdate `json:"product"`
+	}{product}
+	req, err := p.client.NewRequest(method, path, &body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	productWrapper := new(ProductRequest)
+	resp, err := p.client.Do(ctx, req, productWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return productWrapper.Product, resp, nil
+}
+
+func (p *ProductService) Delete(ctx context.Context, ID int64) (*http.Response, error) {
+	req, err := p.client.NewRequest("DELETE", fmt.Sprintf("/admin/products/%d.json", ID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.client.Do(ctx, req, nil)
+}
+
 func (p *ProductService) GetVariant(ctx context.Context, variantID int64) (*ProductVariant, *http.Response, error) {
 	req, err := p.client.NewRequest("GET", fmt.Sprintf("/admin/variants/%d.json", variantID), nil)
 	if err != nil {
@@ -143,6 +393,8 @@ func (p *ProductService) GetImages(ctx context.Context, productID int64) ([]*Pro
 	return imagesWrapper.Images, resp, nil
 }
 
+// GetStock returns the inventory quantity of the variant. For shops stocking
+// at several locations, see GetLocationStock.
 func (p *ProductService) GetStock(ctx context.Context, variantID int64) (int, *http.Response, error) {
 	v, resp, err := p.GetVariant(ctx, variantID)
 	if err != nil {
@@ -151,3 +403,39 @@ func (p *ProductService) GetStock(ctx context.Context, variantID int64) (int, *h
 
 	return v.InventoryQuantity, resp, err
 }
+
+// GetLocationStock returns the quantity of the inventory items, ie. of
+// ProductVariant.InventoryItemID, available at each location they are
+// stocked at. The quantities are keyed by InventoryItemID, then by location
+// ID. Items are requested 50 at a time, as Shopify limits. It requires the
+// read_inventory scope.
+func (p *ProductService) GetLocationStock(ctx context.Context, inventoryItemIDs ...int64) (map[int64]map[int64]int, *http.Response, error) {
+	const maxInventoryItemIDs = 50
+
+	stock := make(map[int64]map[int64]int, len(inventoryItemIDs))
+	var resp *http.Response
+	for len(inventoryItemIDs) > 0 {
+		ids := inventoryItemIDs
+		if len(ids) > maxInventoryItemIDs {
+			ids = ids[:maxInventoryItemIDs]
+		}
+		inventoryItemIDs = inventoryItemIDs[len(ids):]
+
+		it := p.client.InventoryLevel.Iter(&InventoryLevelParam{
+			InventoryItemIDs: ids,
+			Limit:            250,
+		})
+		for it.Next(ctx) {
+			l := it.Value()
+			if stock[l.InventoryItemID] == nil {
+				stock[l.InventoryItemID] = make(map[int64]int)
+			}
+			stock[l.InventoryItemID][l.LocationID] = l.Available
+		}
+		resp = it.Response()
+		if err := it.Err(); err != nil {
+			return nil, resp, err
+		}
+	}
+	return stock, resp, nil
+}
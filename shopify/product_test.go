@@ -0,0 +1,185 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProductParam(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2019, 3, 29, 13, 2, 8, 0, time.UTC)
+	inputs := []struct {
+		name     string
+		params   *ProductParam
+		expected string
+	}{
+		{"nil", nil, ""},
+		{
+			"filters",
+			&ProductParam{
+				IDs:             []int64{1, 2},
+				Vendor:          "Acme",
+				ProductType:     "Shirts",
+				CollectionID:    3,
+				Status:          ProductStatusActive,
+				PublishedStatus: ProductPublishedStatusPublished,
+				UpdatedAtMin:    &since,
+				Fields:          []string{"id", "title"},
+				Limit:           50,
+			},
+			"collection_id=3&fields=id%2Ctitle&ids=1%2C2&limit=50&product_type=Shirts&published_status=published" +
+				"&status=active&updated_at_min=2019-03-29T13%3A02%3A08%2B00%3A00&vendor=Acme",
+		},
+		{
+			"cursor",
+			&ProductParam{Vendor: "Acme", Limit: 50, PageInfo: "abc"},
+			"limit=50&page_info=abc",
+		},
+	}
+
+	for _, tt := range inputs {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if q := tt.params.EncodeQuery(); q != tt.expected {
+				t.Errorf("expected %q got %q", tt.expected, q)
+			}
+		})
+	}
+}
+
+func TestProductCreate(t *testing.T) {
+	t.Parallel()
+
+	c, last, teardown := setup(http.StatusCreated, `{"product":{"id":1,"title":"Example T-Shirt","status":"draft","published_at":null}}`)
+	defer teardown()
+
+	status := ProductStatusDraft
+	product, _, err := c.Product.Create(context.Background(), &ProductUpdate{
+		Title:     String("Example T-Shirt"),
+		Status:    &status,
+		Published: Bool(false),
+		Variants: []*VariantUpdate{
+			{Price: String("19.99"), Taxable: Bool(false)},
+		},
+		Options: []*ProductOptionUpdate{
+			{Name: String("Size"), Values: Strings("Small")},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	// unset fields are left out of the request while explicit zero values
+	// are sent
+	expected := `{"product":{"title":"Example T-Shirt","status":"draft","published":false,` +
+		`"variants":[{"price":"19.99","taxable":false}],"options":[{"name":"Size","values":["Small"]}]}}`
+	if body := strings.TrimSpace(last().Body); body != expected {
+		t.Errorf("expected body %s got %s", expected, body)
+	}
+	if product.ID != 1 || !product.PublishedAt.IsZero() {
+		t.Errorf("unexpected product %+v", product)
+	}
+}
+
+func TestProductUpdate(t *testing.T) {
+	t.Parallel()
+
+	c, last, teardown := setup(http.StatusOK, `{"product":{"id":1,"title":"Example T-Shirt","template_suffix":null}}`)
+	defer teardown()
+
+	product, _, err := c.Product.Update(context.Background(), &ProductUpdate{
+		ID:             1,
+		Tags:           String(""),
+		TemplateSuffix: &NullString{},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	r := last()
+	if r.Method != "PUT" || r.Path != "/admin/products/1.json" {
+		t.Errorf("unexpected request %s %s", r.Method, r.Path)
+	}
+	// variants, options and images left out are not replaced
+	if expected := `{"product":{"id":1,"template_suffix":null,"tags":""}}`; strings.TrimSpace(r.Body) != expected {
+		t.Errorf("expected body %s got %s", expected, r.Body)
+	}
+	if product.ID != 1 || product.Title != "Example T-Shirt" {
+		t.Errorf("unexpected product %+v", product)
+	}
+}
+
+func TestGetLocationStock(t *testing.T) {
+	t.Parallel()
+
+	c, last, teardown := setup(http.StatusOK, `{"inventory_levels":[
+		{"inventory_item_id":808950810,"location_id":10,"available":3},
+		{"inventory_item_id":808950810,"location_id":20,"available":4},
+		{"inventory_item_id":39072856,"location_id":10,"available":0}
+	]}`)
+	defer teardown()
+
+	stock, _, err := c.Product.GetLocationStock(context.Background(), 808950810, 39072856)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if r := last(); r.Path != "/admin/inventory_levels.json" || r.Query != "inventory_item_ids=808950810%2C39072856&limit=250" {
+		t.Errorf("unexpected request %s?%s", r.Path, r.Query)
+	}
+	if len(stock) != 2 || stock[808950810][10] != 3 || stock[808950810][20] != 4 || len(stock[39072856]) != 1 {
+		t.Errorf("unexpected stock by item and location %v", stock)
+	}
+}
+
+func TestGetLocationStockChunks(t *testing.T) {
+	t.Parallel()
+
+	c, last, teardown := setup(http.StatusOK, `{"inventory_levels":[]}`)
+	defer teardown()
+
+	// no items, no request
+	stock, _, err := c.Product.GetLocationStock(context.Background())
+	if err != nil || stock == nil || len(stock) != 0 {
+		t.Errorf("expected an empty stock, got %v %v", stock, err)
+	}
+	if r := last(); r.Path != "" {
+		t.Errorf("expected no request, got %s?%s", r.Path, r.Query)
+	}
+
+	ids := make([]int64, 60)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+	if _, _, err := c.Product.GetLocationStock(context.Background(), ids...); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	// the last 10 items are requested on their own
+	expected := "inventory_item_ids=51%2C52%2C53%2C54%2C55%2C56%2C57%2C58%2C59%2C60&limit=250"
+	if r := last(); r.Query != expected {
+		t.Errorf("expected %s, got %s", expected, r.Query)
+	}
+}
+
+func TestGetStock(t *testing.T) {
+	t.Parallel()
+
+	c, last, teardown := setup(http.StatusOK, `{"variant":{"id":1,"inventory_item_id":808950810,"inventory_quantity":7}}`)
+	defer teardown()
+
+	// the stock of a variant is read from the variant alone
+	total, _, err := c.Product.GetStock(context.Background(), 1)
+	if err != nil || total != 7 {
+		t.Errorf("expected a stock of 7, got %d %v", total, err)
+	}
+	if r := last(); r.Path != "/admin/variants/1.json" {
+		t.Errorf("unexpected request %s", r.Path)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// api reference: https://help.shopify.com/en/api/reference/products/product-image
+
+// Limits of the images Shopify accepts.
+const (
+	MaxImageSize      = 20 << 20 // bytes
+	MaxImageDimension = 4472     // pixels, width or height
+)
+
+var (
+	ErrInvalidImage  = errors.New("invalid image")
+	ErrImageTooLarge = errors.New("image too large")
+)
+
+type ProductImageRequest struct {
+	Image *ProductImage `json:"image"`
+}
+
+// ProductImageUpdate is a product image to create, or a partial update of
+// one. Only the fields that are set are sent, see VariantUpdate.
+type ProductImageUpdate struct {
+	ID         int64       `json:"id,omitempty"`
+	Position   *int        `json:"position,omitempty"`
+	Alt        *NullString `json:"alt,omitempty"`
+	Src        *string     `json:"src,omitempty"`
+	VariantIDs *[]int64    `json:"variant_ids,omitempty"`
+
+	// Base64 encoded image to upload instead of Src, see NewProductImage
+	Attachment string `json:"attachment,omitempty"`
+	Filename   string `json:"filename,omitempty"`
+}
+
+// decodeImageConfig decodes the dimensions of a gif, jpeg or png image, the
+// formats Shopify accepts. The decoders are called directly rather than
+// registered with the image package, which would register them for every
+// importer of this package.
+func decodeImageConfig(data []byte) (image.Config, error) {
+	r := bytes.NewReader(data)
+	switch ct := http.DetectContentType(data); ct {
+	case "image/gif":
+		return gif.DecodeConfig(r)
+	case "image/jpeg":
+		return jpeg.DecodeConfig(r)
+	case "image/png":
+		return png.DecodeConfig(r)
+	default:
+		return image.Config{}, fmt.Errorf("unsupported format %s", ct)
+	}
+}
+
+// NewProductImage reads an image to upload as a product image. The image is
+// checked against Shopify's limits before it is sent.
+func NewProductImage(r io.Reader, filename string) (*ProductImageUpdate, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(r, MaxImageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxImageSize {
+		return nil, fmt.Errorf("%w: %s is over %d bytes", ErrImageTooLarge, filename, MaxImageSize)
+	}
+
+	config, err := decodeImageConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidImage, filename, err)
+	}
+	if config.Width > MaxImageDimension || config.Height > MaxImageDimension {
+		return nil, fmt.Errorf(
+			"%w: %s is %dx%d, over %dx%d",
+			ErrImageTooLarge, filename, config.Width, config.Height, MaxImageDimension, MaxImageDimension,
+		)
+	}
+
+	return &ProductImageUpdate{
+		Attachment: base64.StdEncoding.EncodeToString(data),
+		Filename:   filename,
+	}, nil
+}
+
+// NewProductImageFromFile reads the image file at path to upload as a product
+// image.
+func NewProductImageFromFile(path string) (*ProductImageUpdate, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return NewProductImage(f, filepath.Base(path))
+}
+
+// CreateImage adds an image to the product, either from a url:
+//
+//	client.Product.CreateImage(ctx, productID, &shopify.ProductImageUpdate{Src: shopify.String("https://example.com/shirt.png")})
+//
+// or uploaded from a file or reader:
+//
+//	image, err := shopify.NewProductImageFromFile("shirt.png")
+//	...
+//	image.Alt = shopify.NullableString("Red shirt")
+//	image.VariantIDs = shopify.Int64s(variantID)
+//	client.Product.CreateImage(ctx, productID, image)
+func (p *ProductService) CreateImage(ctx context.Context, productID int64, image *ProductImageUpdate) (*ProductImage, *http.Response, error) {
+	return p.saveImage(ctx, "POST", fmt.Sprintf("/admin/products/%d/images.json", productID), image)
+}
+
+// UpdateImage updates the image with the fields that are set, ie. its
+// position, alt text or variants. Set VariantIDs to an empty list, ie.
+// Int64s(), to detach the image from every variant.
+func (p *ProductService) UpdateImage(ctx context.Context, productID int64, image *ProductImageUpdate) (*ProductImage, *http.Response, error) {
+	return p.saveImage(ctx, "PUT", fmt.Sprintf("/admin/products/%d/images/%d.json", productID, image.ID), image)
+}
+
+func (p *ProductService) saveImage(ctx context.Context, method, path string, image *ProductImageUpdate) (*ProductImage, *http.Response, error) {
+	body := struct {
+		Image *ProductImageUpdate `json:"image"`
+	}{image}
+	req, err := p.client.NewRequest(method, path, &body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	imageWrapper := new(ProductImageRequest)
+	resp, err := p.client.Do(ctx, req, imageWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return imageWrapper.Image, resp, nil
+}
+
+func (p *ProductService) DeleteImage(ctx context.Context, productID, ID int64) (*http.Response, error) {
+	req, err := p.client.NewRequest("DELETE", fmt.Sprintf("/admin/products/%d/images/%d.json", productID, ID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.client.Do(ctx, req, nil)
+}
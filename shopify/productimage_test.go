@@ -0,0 +1,119 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/color/palette"
+	"image/gif"
+	"image/png"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNewProductImage(t *testing.T) {
+	t.Parallel()
+
+	encode := func(width, height int) []byte {
+		var buf bytes.Buffer
+		png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height)))
+		return buf.Bytes()
+	}
+
+	inputs := []struct {
+		name     string
+		data     []byte
+		expected error
+	}{
+		{"valid", encode(10, 20), nil},
+		{"gif", func() []byte {
+			var buf bytes.Buffer
+			gif.Encode(&buf, image.NewPaletted(image.Rect(0, 0, 10, 20), palette.Plan9), nil)
+			return buf.Bytes()
+		}(), nil},
+		{"too wide", encode(MaxImageDimension+1, 1), ErrImageTooLarge},
+		{"not an image", []byte("not an image"), ErrInvalidImage},
+	}
+
+	for _, tt := range inputs {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			img, err := NewProductImage(bytes.NewReader(tt.data), "shirt.png")
+			if tt.expected != nil {
+				if !errors.Is(err, tt.expected) {
+					t.Errorf("expected %v, got %v", tt.expected, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if img.Filename != "shirt.png" || img.Attachment != base64.StdEncoding.EncodeToString(tt.data) {
+				t.Errorf("unexpected image %+v", img)
+			}
+		})
+	}
+
+	_, err := NewProductImage(strings.NewReader(strings.Repeat("x", MaxImageSize+1)), "huge.png")
+	if !errors.Is(err, ErrImageTooLarge) {
+		t.Errorf("expected ErrImageTooLarge for an oversized file, got %v", err)
+	}
+}
+
+func TestUpdateImage(t *testing.T) {
+	t.Parallel()
+
+	c, last, teardown := setup(http.StatusOK, `{"image":{"id":2,"product_id":1,"variant_ids":[]}}`)
+	defer teardown()
+
+	// an empty list detaches the image from its variants
+	image, _, err := c.Product.UpdateImage(context.Background(), 1, &ProductImageUpdate{ID: 2, VariantIDs: Int64s()})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	r := last()
+	if r.Method != "PUT" || r.Path != "/admin/products/1/images/2.json" {
+		t.Errorf("unexpected request %s %s", r.Method, r.Path)
+	}
+	if expected := `{"image":{"id":2,"variant_ids":[]}}`; strings.TrimSpace(r.Body) != expected {
+		t.Errorf("expected body %s got %s", expected, r.Body)
+	}
+	if image.ID != 2 || len(image.VariantIds) != 0 {
+		t.Errorf("unexpected image %+v", image)
+	}
+}
+
+func TestCreateImage(t *testing.T) {
+	t.Parallel()
+
+	c, last, teardown := setup(http.StatusOK, `{"image":{"id":2,"product_id":1,"alt":"Red shirt","variant_ids":[3]}}`)
+	defer teardown()
+
+	image, _, err := c.Product.CreateImage(context.Background(), 1, &ProductImageUpdate{
+		Src:        String("https://example.com/shirt.png"),
+		Alt:        NullableString("Red shirt"),
+		VariantIDs: Int64s(3),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	r := last()
+	if r.Method != "POST" || r.Path != "/admin/products/1/images.json" {
+		t.Errorf("unexpected request %s %s", r.Method, r.Path)
+	}
+	if expected := `{"image":{"alt":"Red shirt","src":"https://example.com/shirt.png","variant_ids":[3]}}`; strings.TrimSpace(r.Body) != expected {
+		t.Errorf("expected body %s got %s", expected, r.Body)
+	}
+	if image.ID != 2 || image.Alt != "Red shirt" || len(image.VariantIds) != 1 {
+		t.Errorf("unexpected image %+v", image)
+	}
+}
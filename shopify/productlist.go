@@ -10,7 +10,6 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"strings"
 	"time"
 )
 
@@ -25,6 +24,7 @@ type ProductListParam struct {
 	Limit        int
 	Page         int
 	UpdatedAtMin time.Time
+	PageInfo     string
 }
 
 const timeFormat = "2006-01-02T15:04:05-07:00"
@@ -36,19 +36,19 @@ func (p *ProductListParam) EncodeQuery() string {
 	// for now just allow handle
 	// TODO: support all params
 	v := url.Values{}
-	v.Add("handle", p.Handle)
-	v.Add("page", fmt.Sprintf("%d", p.Page))
-	if len(p.ProductIDs) > 0 {
-		s := make([]string, len(p.ProductIDs))
-		for i, id := range p.ProductIDs {
-			s[i] = fmt.Sprintf("%d", id)
-		}
-		v.Add("product_ids", strings.Join(s, ","))
-	}
 	if p.Limit > 0 {
 		v.Add("limit", fmt.Sprintf("%d", p.Limit))
 	}
-	return v.Encode()
+	if p.Handle != "" {
+		v.Add("handle", p.Handle)
+	}
+	if p.Page > 0 {
+		v.Add("page", fmt.Sprintf("%d", p.Page))
+	}
+	if len(p.ProductIDs) > 0 {
+		v.Add("product_ids", joinIDs(p.ProductIDs))
+	}
+	return encodeQuery(v, p.PageInfo)
 }
 
 func (p *ProductListService) Get(ctx context.Context, params *ProductListParam) ([]*ProductList, *http.Response, error) {
@@ -70,6 +70,27 @@ func (p *ProductListService) Get(ctx context.Context, params *ProductListParam)
 	return productListWrapper.ProductListings, resp, nil
 }
 
+// ProductListIterator iterates over product listings, see Pagination.
+type ProductListIterator struct{ iterator }
+
+// Value returns the current product listing.
+func (it *ProductListIterator) Value() *ProductList {
+	v, _ := it.value().(*ProductList)
+	return v
+}
+
+// Iter returns an iterator over every product listing matching params.
+func (p *ProductListService) Iter(params *ProductListParam) *ProductListIterator {
+	var pp ProductListParam
+	if params != nil {
+		pp = *params
+	}
+	return &ProductListIterator{newIterator(func(ctx context.Context, pageInfo string) (interface{}, *http.Response, error) {
+		pp.PageInfo = pageInfo
+		return p.Get(ctx, &pp)
+	})}
+}
+
 // fetch one product by the given product id
 func (p *ProductListService) GetProduct(ctx context.Context, ID int64) (*ProductList, *http.Response, error) {
 	req, err := p.client.NewRequest("GET", fmt.Sprintf("/admin/product_listings/%d.json", ID), nil)
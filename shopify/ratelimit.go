@@ -0,0 +1,199 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Shopify throttles the REST admin API with a leaky bucket per shop. Every
+// request adds one call to the bucket, which leaks at a fixed rate. The current
+// fill level is reported back on every response in the form of "32/40".
+//
+// Shopify API docs: https://help.shopify.com/en/api/reference/rest-admin-api-rate-limits
+const (
+	callLimitHeader = `X-Shopify-Shop-Api-Call-Limit`
+
+	// DefaultBucketSize is the bucket capacity of a standard shop.
+	DefaultBucketSize = 40
+	// DefaultLeakRate is the number of calls leaked per second from the bucket
+	// of a standard shop.
+	DefaultLeakRate = 2.0
+)
+
+// BucketState is a snapshot of a shop's leaky bucket.
+type BucketState struct {
+	// Used is the estimated number of calls currently in the bucket.
+	Used float64
+	// Capacity is the size of the bucket.
+	Capacity int
+	// UpdatedAt is the last time the bucket was synced with a response from
+	// Shopify.
+	UpdatedAt time.Time
+}
+
+// Remaining returns the number of calls that can be made right away without
+// overflowing the bucket.
+func (s BucketState) Remaining() int {
+	r := s.Capacity - int(math.Ceil(s.Used))
+	if r < 0 {
+		return 0
+	}
+	return r
+}
+
+// RateLimiter models the leaky bucket of every shop it sees and blocks
+// callers before a request that would overflow it is sent. A RateLimiter is
+// safe for concurrent use and can be shared across clients that talk to the
+// same shops.
+type RateLimiter struct {
+	size     int
+	leakRate float64
+
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+type bucket struct {
+	used      float64
+	capacity  int
+	leakedAt  time.Time
+	updatedAt time.Time
+}
+
+// NewRateLimiter returns a RateLimiter for buckets of the given size which
+// leak at leakRate calls per second. Shopify Plus shops have larger buckets
+// and leak faster than the defaults. The capacity reported by Shopify always
+// takes precedence over size once a response has been seen.
+func NewRateLimiter(size int, leakRate float64) *RateLimiter {
+	if size <= 0 {
+		size = DefaultBucketSize
+	}
+	if leakRate <= 0 {
+		leakRate = DefaultLeakRate
+	}
+	return &RateLimiter{
+		size:     size,
+		leakRate: leakRate,
+		buckets:  make(map[string]*bucket),
+		now:      time.Now,
+	}
+}
+
+// RateLimit is an Option to set the rate limiter used by the client. By
+// default every client gets its own RateLimiter with the standard bucket
+// size; passing nil disables client side rate limiting.
+func RateLimit(l *RateLimiter) Option {
+	return func(o *Options) error {
+		o.rateLimiter = l
+		return nil
+	}
+}
+
+// Wait blocks until a call can be made to shop without overflowing its bucket
+// and reserves a spot for it. It returns early with ctx.Err() if the context
+// is canceled before then.
+func (l *RateLimiter) Wait(ctx context.Context, shop string) error {
+	for {
+		l.mu.Lock()
+		b := l.bucket(shop)
+		l.leak(b)
+
+		// wait for enough room in the bucket for one more call
+		overflow := b.used + 1 - float64(b.capacity)
+		if overflow <= 0 {
+			b.used++
+			l.mu.Unlock()
+			return nil
+		}
+		l.mu.Unlock()
+
+		wait := time.Duration(overflow / l.leakRate * float64(time.Second))
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// Update syncs the bucket of shop with the call limit reported by Shopify.
+func (l *RateLimiter) Update(shop string, used, capacity int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(shop)
+	b.used = float64(used)
+	b.capacity = capacity
+	b.leakedAt = l.now()
+	b.updatedAt = b.leakedAt
+}
+
+// State returns the current state of the bucket of shop.
+func (l *RateLimiter) State(shop string) BucketState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b := l.bucket(shop)
+	l.leak(b)
+	return BucketState{
+		Used:      b.used,
+		Capacity:  b.capacity,
+		UpdatedAt: b.updatedAt,
+	}
+}
+
+// bucket returns the bucket of shop, creating an empty one if needed. The
+// caller must hold l.mu.
+func (l *RateLimiter) bucket(shop string) *bucket {
+	b, ok := l.buckets[shop]
+	if !ok {
+		b = &bucket{capacity: l.size, leakedAt: l.now()}
+		l.buckets[shop] = b
+	}
+	return b
+}
+
+// leak drains the bucket for the time elapsed since it last leaked. The
+// caller must hold l.mu.
+func (l *RateLimiter) leak(b *bucket) {
+	now := l.now()
+	b.used -= now.Sub(b.leakedAt).Seconds() * l.leakRate
+	if b.used < 0 {
+		b.used = 0
+	}
+	b.leakedAt = now
+}
+
+// parseCallLimit parses the call limit header, ie. "32/40".
+func parseCallLimit(r *http.Response) (used, capacity int, ok bool) {
+	h := r.Header.Get(callLimitHeader)
+	if h == "" {
+		return 0, 0, false
+	}
+	parts := strings.SplitN(h, "/", 2)
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+	used, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, false
+	}
+	capacity, err = strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil || capacity <= 0 {
+		return 0, 0, false
+	}
+	return used, capacity, true
+}
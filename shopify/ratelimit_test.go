@@ -0,0 +1,128 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParseCallLimit(t *testing.T) {
+	t.Parallel()
+
+	inputs := []struct {
+		header   string
+		used     int
+		capacity int
+		ok       bool
+	}{
+		{"32/40", 32, 40, true},
+		{" 1 / 80 ", 1, 80, true},
+		{"", 0, 0, false},
+		{"32", 0, 0, false},
+		{"a/40", 0, 0, false},
+		{"1/0", 0, 0, false},
+	}
+
+	for _, tt := range inputs {
+		r := &http.Response{Header: http.Header{}}
+		r.Header.Set(callLimitHeader, tt.header)
+		used, capacity, ok := parseCallLimit(r)
+		if used != tt.used || capacity != tt.capacity || ok != tt.ok {
+			t.Errorf("%q: expected %d/%d %v got %d/%d %v", tt.header, tt.used, tt.capacity, tt.ok, used, capacity, ok)
+		}
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	l := NewRateLimiter(DefaultBucketSize, DefaultLeakRate)
+	l.now = func() time.Time { return now }
+
+	l.Update("x.myshopify.com", 39, 40)
+	if err := l.Wait(context.Background(), "x.myshopify.com"); err != nil {
+		t.Fatalf("expected room for one call, got %v", err)
+	}
+	if s := l.State("x.myshopify.com"); s.Remaining() != 0 {
+		t.Errorf("expected full bucket, got %+v", s)
+	}
+
+	// the bucket is full and the clock is frozen, so the next call blocks
+	// until the context gives up.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx, "x.myshopify.com"); err != context.DeadlineExceeded {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+
+	// other shops have their own bucket
+	if err := l.Wait(context.Background(), "y.myshopify.com"); err != nil {
+		t.Errorf("expected empty bucket for another shop, got %v", err)
+	}
+
+	// one second leaks two calls
+	now = now.Add(time.Second)
+	if s := l.State("x.myshopify.com"); s.Remaining() != 2 {
+		t.Errorf("expected 2 calls remaining, got %+v", s)
+	}
+}
+
+func TestClientRateLimit(t *testing.T) {
+	t.Parallel()
+
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set(callLimitHeader, "40/40")
+		w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	inputs := []struct {
+		name    string
+		options []Option
+		limited bool
+	}{
+		{"default", nil, true},
+		{"disabled", []Option{RateLimit(nil)}, false},
+	}
+
+	for _, tt := range inputs {
+		atomic.StoreInt32(&calls, 0)
+		c, _ := NewClient(nil, append([]Option{ShopURL(ts.URL)}, tt.options...)...)
+		do := func(ctx context.Context) error {
+			req, _ := c.NewRequest("GET", "/admin/shop.json", nil)
+			_, err := c.Do(ctx, req, nil)
+			return err
+		}
+
+		if err := do(context.Background()); err != nil {
+			t.Fatalf("%s: unexpected error %v", tt.name, err)
+		}
+		// the client syncs its bucket with the call limit of the response
+		if s := c.RateLimitState(); tt.limited != (s.Capacity == 40 && s.Remaining() == 0) {
+			t.Errorf("%s: unexpected rate limit state %+v", tt.name, s)
+		}
+
+		// a full bucket blocks the next request until the context gives up
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		err := do(ctx)
+		cancel()
+		if tt.limited && (!errors.Is(err, context.DeadlineExceeded) || atomic.LoadInt32(&calls) != 1) {
+			t.Errorf("%s: expected to wait on the rate limiter, got %v after %d calls", tt.name, err, atomic.LoadInt32(&calls))
+		}
+		if !tt.limited && (err != nil || atomic.LoadInt32(&calls) != 2) {
+			t.Errorf("%s: expected no rate limiting, got %v after %d calls", tt.name, err, atomic.LoadInt32(&calls))
+		}
+	}
+}
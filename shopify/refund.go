@@ -0,0 +1,213 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// api reference: https://help.shopify.com/en/api/reference/orders/refund
+
+type RefundService service
+
+type Refund struct {
+	ID       int64  `json:"id,omitempty"`
+	OrderID  int64  `json:"order_id,omitempty"`
+	UserID   int64  `json:"user_id,omitempty"`
+	Note     string `json:"note,omitempty"`
+	Currency string `json:"currency,omitempty"`
+	// Notify the customer of the refund, on create only
+	Notify bool `json:"notify,omitempty"`
+	// Deprecated: use the RestockType of the refund line items.
+	Restock bool `json:"restock,omitempty"`
+
+	Shipping         *RefundShipping    `json:"shipping,omitempty"`
+	RefundLineItems  []*RefundLineItem  `json:"refund_line_items,omitempty"`
+	Transactions     []*Transaction     `json:"transactions,omitempty"`
+	OrderAdjustments []*OrderAdjustment `json:"order_adjustments,omitempty"`
+
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	ProcessedAt *time.Time `json:"processed_at,omitempty"`
+}
+
+type RefundRestockType string
+
+const (
+	// The items are not restocked.
+	RefundRestockTypeNoRestock RefundRestockType = "no_restock"
+	// The items were never fulfilled and are returned to stock.
+	RefundRestockTypeCancel RefundRestockType = "cancel"
+	// The items were fulfilled and are returned to stock.
+	RefundRestockTypeReturn RefundRestockType = "return"
+)
+
+type RefundLineItem struct {
+	ID          int64             `json:"id,omitempty"`
+	LineItemID  int64             `json:"line_item_id"`
+	Quantity    int               `json:"quantity"`
+	RestockType RefundRestockType `json:"restock_type,omitempty"`
+	// Location to restock the items at, required to restock
+	LocationID int64 `json:"location_id,omitempty"`
+
+	// Subtotal and tax of the refunded items, set by Shopify. Unlike other
+	// amounts, they are sent as numbers.
+	Subtotal json.Number `json:"subtotal,omitempty"`
+	TotalTax json.Number `json:"total_tax,omitempty"`
+
+	LineItem *OrderLineItem `json:"line_item,omitempty"`
+}
+
+// RefundShipping is the shipping to refund. Either refund it in full, or a
+// given amount.
+type RefundShipping struct {
+	FullRefund bool   `json:"full_refund,omitempty"`
+	Amount     string `json:"amount,omitempty"`
+
+	// Set by Calculate
+	Tax               string `json:"tax,omitempty"`
+	MaximumRefundable string `json:"maximum_refundable,omitempty"`
+}
+
+// OrderAdjustment is a refund of shipping, or a discrepancy between the
+// refunded amount and the refunded items.
+type OrderAdjustment struct {
+	ID        int64  `json:"id"`
+	OrderID   int64  `json:"order_id"`
+	RefundID  int64  `json:"refund_id"`
+	Amount    string `json:"amount"`
+	TaxAmount string `json:"tax_amount"`
+	Kind      string `json:"kind"`
+	Reason    string `json:"reason"`
+}
+
+type RefundParam struct {
+	Limit int
+	// Return the amounts in the shop's currency instead of the order's
+	InShopCurrency bool
+	Fields         []string
+	PageInfo       string
+}
+
+type RefundRequest struct {
+	Refund *Refund `json:"refund"`
+}
+
+func (p *RefundParam) EncodeQuery() string {
+	if p == nil {
+		return ""
+	}
+	v := url.Values{}
+	if p.Limit > 0 {
+		v.Add("limit", fmt.Sprintf("%d", p.Limit))
+	}
+	if p.InShopCurrency {
+		v.Add("in_shop_currency", "true")
+	}
+	if len(p.Fields) > 0 {
+		v.Add("fields", strings.Join(p.Fields, ","))
+	}
+	return encodeQuery(v, p.PageInfo)
+}
+
+func (s *RefundService) List(ctx context.Context, orderID int64, params *RefundParam) ([]*Refund, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("/admin/orders/%d/refunds.json", orderID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.URL.RawQuery = params.EncodeQuery()
+
+	var refundWrapper struct {
+		Refunds []*Refund `json:"refunds"`
+	}
+	resp, err := s.client.Do(ctx, req, &refundWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return refundWrapper.Refunds, resp, nil
+}
+
+// RefundIterator iterates over refunds, see Pagination.
+type RefundIterator struct{ iterator }
+
+// Value returns the current refund.
+func (it *RefundIterator) Value() *Refund {
+	v, _ := it.value().(*Refund)
+	return v
+}
+
+// Iter returns an iterator over every refund of the order.
+func (s *RefundService) Iter(orderID int64, params *RefundParam) *RefundIterator {
+	var pp RefundParam
+	if params != nil {
+		pp = *params
+	}
+	return &RefundIterator{newIterator(func(ctx context.Context, pageInfo string) (interface{}, *http.Response, error) {
+		pp.PageInfo = pageInfo
+		return s.List(ctx, orderID, &pp)
+	})}
+}
+
+func (s *RefundService) Get(ctx context.Context, orderID, ID int64) (*Refund, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("/admin/orders/%d/refunds/%d.json", orderID, ID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	refundWrapper := new(RefundRequest)
+	resp, err := s.client.Do(ctx, req, refundWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return refundWrapper.Refund, resp, nil
+}
+
+// Calculate computes the refund of the given line items and shipping, without
+// creating it. The returned refund holds the amounts to refund and the
+// transactions to refund them with, as suggested_refund transactions.
+func (s *RefundService) Calculate(ctx context.Context, orderID int64, refund *Refund) (*Refund, *http.Response, error) {
+	return s.post(ctx, fmt.Sprintf("/admin/orders/%d/refunds/calculate.json", orderID), refund)
+}
+
+// Create creates a refund. The transactions of a calculated refund can be
+// used after setting their kind to refund:
+//
+//	calculated, _, err := client.Refund.Calculate(ctx, orderID, refund)
+//	...
+//	for _, t := range calculated.Transactions {
+//		refund.Transactions = append(refund.Transactions, &shopify.Transaction{
+//			ParentID: t.ParentID,
+//			Amount:   t.Amount,
+//			Gateway:  t.Gateway,
+//			Kind:     shopify.TransactionKindRefund,
+//		})
+//	}
+//	client.Refund.Create(ctx, orderID, refund)
+func (s *RefundService) Create(ctx context.Context, orderID int64, refund *Refund) (*Refund, *http.Response, error) {
+	return s.post(ctx, fmt.Sprintf("/admin/orders/%d/refunds.json", orderID), refund)
+}
+
+func (s *RefundService) post(ctx context.Context, path string, refund *Refund) (*Refund, *http.Response, error) {
+	req, err := s.client.NewRequest("POST", path, &RefundRequest{refund})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	refundWrapper := new(RefundRequest)
+	resp, err := s.client.Do(ctx, req, refundWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return refundWrapper.Refund, resp, nil
+}
@@ -0,0 +1,150 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestRefundCalculate(t *testing.T) {
+	t.Parallel()
+
+	c, last, teardown := setup(http.StatusOK, `{"refund":{
+		"shipping":{"amount":"5.00","tax":"0.00","maximum_refundable":"5.00"},
+		"refund_line_items":[{"line_item_id":2,"quantity":1,"restock_type":"return","location_id":3,"subtotal":"10.00","total_tax":0.5}],
+		"transactions":[{"order_id":1,"parent_id":4,"amount":"15.50","kind":"suggested_refund","gateway":"bogus","maximum_refundable":"20.00"}]
+	}}`)
+	defer teardown()
+
+	refund, _, err := c.Refund.Calculate(context.Background(), 1, &Refund{
+		Shipping: &RefundShipping{FullRefund: true},
+		RefundLineItems: []*RefundLineItem{
+			{LineItemID: 2, Quantity: 1, RestockType: RefundRestockTypeReturn, LocationID: 3},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	r := last()
+	if r.Path != "/admin/orders/1/refunds/calculate.json" {
+		t.Errorf("unexpected path %s", r.Path)
+	}
+	var body RefundRequest
+	json.Unmarshal([]byte(r.Body), &body)
+	if r := body.Refund; r == nil || !r.Shipping.FullRefund || r.RefundLineItems[0].RestockType != RefundRestockTypeReturn {
+		t.Errorf("unexpected request body %+v", r)
+	}
+
+	if refund.Shipping.MaximumRefundable != "5.00" {
+		t.Errorf("expected shipping maximum refundable 5.00, got %q", refund.Shipping.MaximumRefundable)
+	}
+	if tax, err := refund.RefundLineItems[0].TotalTaxMoney("USD"); err != nil || tax.String() != "0.5" {
+		t.Errorf("expected total tax 0.5, got %s (%v)", tax, err)
+	}
+	if subtotal, _ := refund.RefundLineItems[0].SubtotalMoney("USD"); subtotal.String() != "10.00" {
+		t.Errorf("expected subtotal 10.00, got %s", subtotal)
+	}
+	if tr := refund.Transactions[0]; tr.Kind != TransactionKindSuggestedRefund || tr.MaximumRefundable != "20.00" {
+		t.Errorf("unexpected suggested transaction %+v", tr)
+	}
+}
+
+func TestRefundCreate(t *testing.T) {
+	t.Parallel()
+
+	calc, _, teardownCalc := setup(http.StatusOK, `{"refund":{
+		"transactions":[{"order_id":1,"parent_id":4,"amount":"15.50","kind":"suggested_refund","gateway":"bogus","maximum_refundable":"20.00"}]
+	}}`)
+	defer teardownCalc()
+	c, last, teardown := setup(http.StatusCreated, `{"refund":{"id":5,"order_id":1,
+		"transactions":[{"id":6,"order_id":1,"parent_id":4,"amount":"15.50","kind":"refund","gateway":"bogus","status":"success"}]
+	}}`)
+	defer teardown()
+
+	// the suggested transactions of a calculated refund are sent back as
+	// refund transactions
+	refund := &Refund{Shipping: &RefundShipping{FullRefund: true}}
+	calculated, _, err := calc.Refund.Calculate(context.Background(), 1, refund)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	for _, tr := range calculated.Transactions {
+		refund.Transactions = append(refund.Transactions, &Transaction{
+			ParentID: tr.ParentID,
+			Amount:   tr.Amount,
+			Gateway:  tr.Gateway,
+			Kind:     TransactionKindRefund,
+		})
+	}
+	created, _, err := c.Refund.Create(context.Background(), 1, refund)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	r := last()
+	if r.Method != "POST" || r.Path != "/admin/orders/1/refunds.json" {
+		t.Errorf("unexpected request %s %s", r.Method, r.Path)
+	}
+	var body RefundRequest
+	json.Unmarshal([]byte(r.Body), &body)
+	if b := body.Refund; b == nil || len(b.Transactions) != 1 {
+		t.Fatalf("unexpected request body %s", r.Body)
+	}
+	if tr := body.Refund.Transactions[0]; tr.Kind != TransactionKindRefund || tr.ParentID != 4 || tr.Amount != "15.50" || tr.Gateway != "bogus" {
+		t.Errorf("unexpected refund transaction %+v", tr)
+	}
+	if created.ID != 5 || created.Transactions[0].Status != TransactionStatusSuccess {
+		t.Errorf("unexpected refund %+v", created)
+	}
+}
+
+func TestRefundList(t *testing.T) {
+	t.Parallel()
+
+	c, last, teardown := setup(http.StatusOK, `{"refunds":[{"id":5,"order_id":1},{"id":6,"order_id":1}]}`)
+	defer teardown()
+
+	refunds, _, err := c.Refund.List(context.Background(), 1, &RefundParam{Limit: 2, InShopCurrency: true})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if r := last(); r.Path != "/admin/orders/1/refunds.json" || r.Query != "in_shop_currency=true&limit=2" {
+		t.Errorf("unexpected request %s?%s", r.Path, r.Query)
+	}
+	if len(refunds) != 2 || refunds[1].ID != 6 {
+		t.Errorf("unexpected refunds %+v", refunds)
+	}
+
+	var ids []int64
+	it := c.Refund.Iter(1, nil)
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Value().ID)
+	}
+	if err := it.Err(); err != nil || len(ids) != 2 {
+		t.Errorf("expected to iterate over 2 refunds, got %v (%v)", ids, err)
+	}
+}
+
+func TestRefundGet(t *testing.T) {
+	t.Parallel()
+
+	c, last, teardown := setup(http.StatusOK, `{"refund":{"id":5,"order_id":1,"note":"damaged"}}`)
+	defer teardown()
+
+	refund, _, err := c.Refund.Get(context.Background(), 1, 5)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if r := last(); r.Method != "GET" || r.Path != "/admin/orders/1/refunds/5.json" {
+		t.Errorf("unexpected request %s %s", r.Method, r.Path)
+	}
+	if refund.ID != 5 || refund.Note != "damaged" {
+		t.Errorf("unexpected refund %+v", refund)
+	}
+}
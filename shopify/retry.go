@@ -0,0 +1,153 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy decides whether a failed request should be sent again.
+//
+// Retry is called after every attempt with the request, the response (nil if
+// the request failed before getting one) and the transport error, if any.
+// attempt starts at 1 for the first retry. It returns how long to wait
+// before retrying, and false if the request should not be retried.
+type RetryPolicy interface {
+	Retry(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool)
+}
+
+// RetryPolicyFunc is an adapter to allow the use of ordinary functions as a
+// RetryPolicy.
+type RetryPolicyFunc func(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool)
+
+// Retry calls f(req, resp, err, attempt).
+func (f RetryPolicyFunc) Retry(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	return f(req, resp, err, attempt)
+}
+
+// BackoffPolicy is the default RetryPolicy. It retries 429 Too Many Requests
+// after the delay given in Retry-After, and 5xx responses and transient
+// network errors with jittered exponential backoff. A 429 asking to wait
+// longer than MaxBackoff is not retried, since retrying any sooner would only
+// be throttled again.
+//
+// Non-idempotent requests (POST and PATCH) are never retried unless
+// RetryNonIdempotent is set, since Shopify may have acted on them already.
+type BackoffPolicy struct {
+	// MaxRetries is the maximum number of retries for a single request.
+	MaxRetries int
+	// MinBackoff is the base delay of the exponential backoff.
+	MinBackoff time.Duration
+	// MaxBackoff caps the exponential backoff, and the longest Retry-After
+	// delay waited for.
+	MaxBackoff time.Duration
+	// RetryNonIdempotent allows POST and PATCH requests to be retried.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy retries up to 3 times, backing off from 500ms up to 30s.
+var DefaultRetryPolicy = &BackoffPolicy{
+	MaxRetries: 3,
+	MinBackoff: 500 * time.Millisecond,
+	MaxBackoff: 30 * time.Second,
+}
+
+// Retry is an Option to set the retry policy of the client. Without it, no
+// request is ever retried.
+func Retry(p RetryPolicy) Option {
+	return func(o *Options) error {
+		o.retryPolicy = p
+		return nil
+	}
+}
+
+// Retry satisfies RetryPolicy.
+func (p *BackoffPolicy) Retry(req *http.Request, resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	if attempt > p.MaxRetries {
+		return 0, false
+	}
+	if !p.RetryNonIdempotent && !isIdempotent(req.Method) {
+		return 0, false
+	}
+
+	if err != nil {
+		if !isTransient(err) {
+			return 0, false
+		}
+		return p.backoff(attempt), true
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		if wait, ok := retryAfter(resp); ok {
+			if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+				return 0, false
+			}
+			return wait, true
+		}
+		return p.backoff(attempt), true
+	case resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented:
+		return p.backoff(attempt), true
+	}
+	return 0, false
+}
+
+// backoff returns a "full jitter" exponential delay for the attempt, ie.
+// a random duration between 0 and MinBackoff * 2^(attempt-1).
+func (p *BackoffPolicy) backoff(attempt int) time.Duration {
+	d := p.MinBackoff << uint(attempt-1)
+	if d <= 0 || (p.MaxBackoff > 0 && d > p.MaxBackoff) {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(d)))
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "POST", "PATCH":
+		return false
+	}
+	return true
+}
+
+// isTransient reports whether a transport error is worth retrying.
+func isTransient(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return false
+}
+
+// retryAfter parses the Retry-After header. Shopify sends it in (possibly
+// fractional) seconds, ie. "2.0".
+func retryAfter(r *http.Response) (time.Duration, bool) {
+	h := r.Header.Get("Retry-After")
+	if h == "" {
+		return 0, false
+	}
+	secs, err := strconv.ParseFloat(h, 64)
+	if err != nil || secs < 0 {
+		return 0, false
+	}
+	return time.Duration(secs * float64(time.Second)), true
+}
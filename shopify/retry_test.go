@@ -0,0 +1,105 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu     sync.Mutex
+		calls  int
+		bodies []string
+	)
+	count := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		switch calls {
+		case 1:
+			w.Header().Set("Retry-After", "0.01")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte(`{"webhook":{"id":1}}`))
+		}
+	}))
+	defer ts.Close()
+
+	policy := &BackoffPolicy{MaxRetries: 3, MinBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}
+	c, _ := NewClient(nil, ShopURL(ts.URL), Retry(policy))
+
+	// PUT is idempotent and is retried with its body replayed
+	req, _ := c.NewRequest("PUT", "/admin/webhooks/1.json", &WebhookRequest{&Webhook{Address: "https://x"}})
+	ww := new(WebhookRequest)
+	if _, err := c.Do(context.Background(), req, ww); err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if calls := count(); calls != 3 || ww.Webhook == nil || ww.Webhook.ID != 1 {
+		t.Errorf("expected 3 calls and a decoded webhook, got %d %+v", calls, ww.Webhook)
+	}
+	mu.Lock()
+	for i, b := range bodies {
+		if b != bodies[0] || b == "" {
+			t.Errorf("attempt %d: expected body %q got %q", i, bodies[0], b)
+		}
+	}
+
+	// POST is not retried unless the policy opts in
+	calls = 0
+	mu.Unlock()
+	req, _ = c.NewRequest("POST", "/admin/webhooks.json", &WebhookRequest{&Webhook{}})
+	if _, err := c.Do(context.Background(), req, nil); err == nil {
+		t.Errorf("expected the 429 to be returned")
+	}
+	if calls := count(); calls != 1 {
+		t.Errorf("expected POST to be sent once, got %d", calls)
+	}
+}
+
+func TestBackoffPolicyRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	policy := &BackoffPolicy{MaxRetries: 3, MaxBackoff: time.Second}
+	req, _ := http.NewRequest("GET", "/admin/products.json", nil)
+
+	inputs := []struct {
+		retryAfter string
+		wait       time.Duration
+		retry      bool
+	}{
+		{"0.5", 500 * time.Millisecond, true},
+		{"1.0", time.Second, true},
+		// retrying before shopify allows it would only be throttled again
+		{"2.0", 0, false},
+	}
+
+	for _, tt := range inputs {
+		resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+		resp.Header.Set("Retry-After", tt.retryAfter)
+		wait, retry := policy.Retry(req, resp, nil, 1)
+		if wait != tt.wait || retry != tt.retry {
+			t.Errorf("Retry-After %s: expected %v %v, got %v %v", tt.retryAfter, tt.wait, tt.retry, wait, retry)
+		}
+	}
+}
@@ -15,6 +15,8 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"sync"
+	"time"
 )
 
 type Client struct {
@@ -26,6 +28,9 @@ type Client struct {
 	// client connection options
 	opts Options
 
+	// API version mismatches already logged
+	versionWarnings sync.Map
+
 	common service
 
 	Billing          *BillingService
@@ -41,15 +46,29 @@ type Client struct {
 	DiscountCode     *DiscountCodeService
 	Policy           *PolicyService
 	ShippingZone     *ShippingZoneService
+	Order            *OrderService
+	Transaction      *TransactionService
+	Refund           *RefundService
+	Customer         *CustomerService
+	Location         *LocationService
+	InventoryItem    *InventoryItemService
+	InventoryLevel   *InventoryLevelService
+	Fulfillment      *FulfillmentService
+	FulfillmentOrder *FulfillmentOrderService
 }
 
 // Options can be used to create a customized client
 type Options struct {
-	ShopURL string
-	Token   string
-	Debug   bool // turn on debugging
-
-	baseURL *url.URL
+	ShopURL    string
+	Token      string
+	Debug      bool   // turn on debugging
+	APIVersion string // admin API version, ie. "2019-07"
+
+	baseURL     *url.URL
+	logger      Logger
+	rateLimiter *RateLimiter
+	retryPolicy RetryPolicy
+	poller      *Poller
 }
 
 type Option func(*Options) error
@@ -83,6 +102,31 @@ func Debug(b bool) Option {
 	}
 }
 
+// Logger is where a client writes its warnings. *log.Logger satisfies it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// Log is an Option to set the logger of the client's warnings, ie. when
+// Shopify falls back to another API version. Without it, warnings are only
+// printed in Debug.
+func Log(l Logger) Option {
+	return func(o *Options) error {
+		o.logger = l
+		return nil
+	}
+}
+
+// logf writes a warning to the logger of the client.
+func (c *Client) logf(format string, v ...interface{}) {
+	switch {
+	case c.opts.logger != nil:
+		c.opts.logger.Printf("[shopify] "+format, v...)
+	case c.opts.Debug:
+		fmt.Printf("[shopify] "+format+"\n", v...)
+	}
+}
+
 type service struct {
 	client *Client
 }
@@ -96,11 +140,18 @@ const (
 // NewClient returns a new Shopify API client. If a nil httpClient is
 // provided, http.DefaultClient will be used. To use API methods which require
 // authentication, provide a token that will be sent as part of authHeader.
+//
+// Requests are rate limited by default: a request that would overflow the
+// shop's leaky bucket blocks until enough calls have leaked, or its context is
+// done. Pass RateLimit(nil) to turn it off, or share a RateLimiter between
+// clients of the same shop.
 func NewClient(httpClient *http.Client, options ...Option) (*Client, error) {
 	if httpClient == nil {
 		httpClient = http.DefaultClient
 	}
 	c := &Client{client: httpClient, UserAgent: userAgent}
+	c.opts.rateLimiter = NewRateLimiter(DefaultBucketSize, DefaultLeakRate)
+	c.opts.poller = DefaultPoller
 	for _, opt := range options {
 		if err := opt(&c.opts); err != nil {
 			return nil, err
@@ -122,9 +173,28 @@ func NewClient(httpClient *http.Client, options ...Option) (*Client, error) {
 	c.DiscountCode = (*DiscountCodeService)(&c.common)
 	c.Policy = (*PolicyService)(&c.common)
 	c.ShippingZone = (*ShippingZoneService)(&c.common)
+	c.Order = (*OrderService)(&c.common)
+	c.Transaction = (*TransactionService)(&c.common)
+	c.Refund = (*RefundService)(&c.common)
+	c.Customer = (*CustomerService)(&c.common)
+	c.Location = (*LocationService)(&c.common)
+	c.InventoryItem = (*InventoryItemService)(&c.common)
+	c.InventoryLevel = (*InventoryLevelService)(&c.common)
+	c.Fulfillment = (*FulfillmentService)(&c.common)
+	c.FulfillmentOrder = (*FulfillmentOrderService)(&c.common)
 	return c, nil
 }
 
+// RateLimitState returns the state of the shop's leaky bucket as last
+// reported by Shopify, leaked up to now. Schedulers can use it to back off
+// before the client has to block.
+func (c *Client) RateLimitState() BucketState {
+	if c.opts.rateLimiter == nil || c.opts.baseURL == nil {
+		return BucketState{}
+	}
+	return c.opts.rateLimiter.State(c.opts.baseURL.Host)
+}
+
 func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Request, error) {
 	rel, err := url.Parse(urlStr)
 	if err != nil {
@@ -132,6 +202,7 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 	}
 
 	u := c.opts.baseURL.ResolveReference(rel)
+	u.Path = versionedPath(u.Path, c.opts.APIVersion)
 
 	var buf io.ReadWriter
 	if body != nil {
@@ -177,11 +248,43 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 //
 // The provided ctx must be non-nil. If it is canceled or times out,
 // ctx.Err() will be returned.
-// TODO: Rate limiting
+//
+// If the client has a rate limiter, Do blocks until the shop's bucket has
+// room for the request. If the client has a retry policy, failed requests are
+// sent again as the policy allows, replaying the request body.
+//
+// The API version set on ctx with WithAPIVersion takes precedence over the
+// one of the client.
 func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*http.Response, error) {
 	req = req.WithContext(ctx)
 
-	resp, err := c.client.Do(req)
+	version := c.apiVersion(ctx)
+	if path := versionedPath(req.URL.Path, version); path != req.URL.Path {
+		u := *req.URL
+		u.Path, u.RawPath = path, ""
+		req.URL = &u
+	}
+
+	resp, err := c.send(ctx, req)
+	for attempt := 1; c.opts.retryPolicy != nil && ctx.Err() == nil; attempt++ {
+		wait, ok := c.opts.retryPolicy.Retry(req, resp, err, attempt)
+		if !ok || !rewindBody(req) {
+			break
+		}
+		if resp != nil {
+			drainBody(resp)
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		case <-t.C:
+		}
+
+		resp, err = c.send(ctx, req)
+	}
 	if err != nil {
 		// If we got an error, and the context has been canceled,
 		// the context's error is probably more useful.
@@ -202,11 +305,9 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*htt
 		return nil, err
 	}
 
-	defer func() {
-		// Drain up to 512 bytes and close the body to let the Transport reuse the connection
-		io.CopyN(ioutil.Discard, resp.Body, 512)
-		resp.Body.Close()
-	}()
+	defer drainBody(resp)
+
+	c.checkAPIVersion(version, resp)
 
 	// check for error response
 	err = CheckResponse(resp)
@@ -233,3 +334,49 @@ func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*htt
 
 	return resp, err
 }
+
+// send waits on the rate limiter and sends the request once.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	l := c.opts.rateLimiter
+	if l != nil {
+		if err := l.Wait(ctx, req.URL.Host); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if l != nil {
+		if used, capacity, ok := parseCallLimit(resp); ok {
+			l.Update(req.URL.Host, used, capacity)
+		}
+	}
+	return resp, nil
+}
+
+// rewindBody resets the request body so the request can be sent again. It
+// reports false if the body cannot be replayed.
+func rewindBody(req *http.Request) bool {
+	if req.Body == nil || req.Body == http.NoBody {
+		return true
+	}
+	if req.GetBody == nil {
+		return false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return false
+	}
+	req.Body = body
+	return true
+}
+
+// drainBody drains up to 512 bytes and closes the body to let the Transport
+// reuse the connection.
+func drainBody(resp *http.Response) {
+	io.CopyN(ioutil.Discard, resp.Body, 512)
+	resp.Body.Close()
+}
@@ -0,0 +1,48 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+// request is a request received by a test server.
+type request struct {
+	Method string
+	Path   string
+	Query  string
+	Body   string
+}
+
+// setup starts a test server answering every request with status and body.
+// It returns a client of the server, a func returning the last request the
+// server received and a func to stop it.
+func setup(status int, body string) (*Client, func() request, func()) {
+	var (
+		mu   sync.Mutex
+		last request
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		last = request{r.Method, r.URL.Path, r.URL.RawQuery, string(b)}
+		mu.Unlock()
+
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+
+	c, _ := NewClient(nil, ShopURL(ts.URL))
+	lastRequest := func() request {
+		mu.Lock()
+		defer mu.Unlock()
+		return last
+	}
+	return c, lastRequest, ts.Close
+}
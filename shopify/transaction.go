@@ -6,25 +6,73 @@
 package shopify
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
 	"time"
 )
 
+// api reference: https://help.shopify.com/en/api/reference/orders/transaction
+
+type TransactionService service
+
 type Transaction struct {
-	ID      int64  `json:"id"`
-	Amount  string `json:"amount"`
-	OrderID int64  `json:"order_id"`
+	ID       int64           `json:"id,omitempty"`
+	Kind     TransactionKind `json:"kind,omitempty"`
+	Amount   string          `json:"amount,omitempty"`
+	OrderID  int64           `json:"order_id,omitempty"`
+	ParentID int64           `json:"parent_id,omitempty"`
+	// Largest amount that can be refunded, on suggested refunds
+	MaximumRefundable string `json:"maximum_refundable,omitempty"`
 
 	// Status and error codes
-	ErrorCode string            `json:"error_code"`
-	Status    TransactionStatus `json:"status"`
-	Message   string            `json:"message"`
+	ErrorCode string            `json:"error_code,omitempty"`
+	Status    TransactionStatus `json:"status,omitempty"`
+	Message   string            `json:"message,omitempty"`
+
+	// Gateway
+	Gateway       string `json:"gateway,omitempty"`
+	Authorization string `json:"authorization,omitempty"`
+	SourceName    string `json:"source_name,omitempty"`
+	LocationID    int64  `json:"location_id,omitempty"`
+	DeviceID      int64  `json:"device_id,omitempty"`
+	UserID        int64  `json:"user_id,omitempty"`
 
-	Test     bool   `json:"test"`
-	Currency string `json:"currency"`
+	Test     bool   `json:"test,omitempty"`
+	Currency string `json:"currency,omitempty"`
+
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	ProcessedAt *time.Time `json:"processed_at,omitempty"`
+}
+
+type TransactionKind string
+
+const (
+	// Money that the customer has agreed to pay, held until captured.
+	TransactionKindAuthorization TransactionKind = "authorization"
+	// Transfer of money that was reserved during the authorization.
+	TransactionKindCapture TransactionKind = "capture"
+	// Authorization and capture in one step.
+	TransactionKindSale TransactionKind = "sale"
+	// Cancellation of a pending authorization or capture.
+	TransactionKindVoid TransactionKind = "void"
+	// Return of part or all of a captured amount to the customer.
+	TransactionKindRefund TransactionKind = "refund"
+	// Refund suggested by a refund calculation, not an actual transaction.
+	TransactionKindSuggestedRefund TransactionKind = "suggested_refund"
+)
 
-	CreatedAt *time.Time `json:"created_at"`
+type TransactionParam struct {
+	SinceID  int64
+	Fields   []string
+	PageInfo string
+}
+
+type TransactionRequest struct {
+	Transaction *Transaction `json:"transaction"`
 }
 
 type TransactionStatus uint32
@@ -50,7 +98,7 @@ var (
 	ErrIncorrectNumber   = errors.New("incorrect_number")
 	ErrInvalidNumber     = errors.New("invalid_number")
 	ErrInvalidExpiryDate = errors.New("invalid_expiry_date")
-	ErrInvalidCvc        = errors.New("invalid_expiry_date")
+	ErrInvalidCvc        = errors.New("invalid_cvc")
 	ErrExpiredCard       = errors.New("expired_card")
 	ErrIncorrectCvc      = errors.New("incorrect_cvc")
 	ErrIncorrectZip      = errors.New("incorrect_zip")
@@ -59,8 +107,83 @@ var (
 	ErrProcessingError   = errors.New("processing_error")
 	ErrCallIssuer        = errors.New("call_issuer")
 	ErrPickUpCard        = errors.New("pick_up_card")
+
+	// card errors by transaction error code
+	cardErrors = map[string]error{
+		"incorrect_number":    ErrIncorrectNumber,
+		"invalid_number":      ErrInvalidNumber,
+		"invalid_expiry_date": ErrInvalidExpiryDate,
+		"invalid_cvc":         ErrInvalidCvc,
+		"expired_card":        ErrExpiredCard,
+		"incorrect_cvc":       ErrIncorrectCvc,
+		"incorrect_zip":       ErrIncorrectZip,
+		"incorrect_address":   ErrIncorrectAddress,
+		"card_declined":       ErrCardDeclined,
+		"processing_error":    ErrProcessingError,
+		"call_issuer":         ErrCallIssuer,
+		"pick_up_card":        ErrPickUpCard,
+	}
 )
 
+// PaymentError is returned when a payment or transaction failed. It wraps the
+// card error matching its code, if any, so the reason can be checked with
+// errors.Is:
+//
+//	if errors.Is(err, shopify.ErrCardDeclined) {
+//		...
+//	}
+type PaymentError struct {
+	// Code is the transaction error code, ie. "card_declined"
+	Code string
+	// Message is the error message of the gateway
+	Message string
+
+	Transaction *Transaction
+
+	err error
+}
+
+func (e *PaymentError) Error() string {
+	switch {
+	case e.Code == "":
+		return fmt.Sprintf("payment failed: %s", e.Message)
+	case e.Message == "":
+		return fmt.Sprintf("payment failed: %s", e.Code)
+	}
+	return fmt.Sprintf("payment failed: %s: %s", e.Code, e.Message)
+}
+
+// Unwrap returns the card error matching the error code, or nil if the code
+// is unknown.
+func (e *PaymentError) Unwrap() error {
+	return e.err
+}
+
+// transactionError returns a *PaymentError if the transaction failed or a
+// processing error message was given, and nil otherwise.
+func transactionError(t *Transaction, message string) error {
+	failed := message != ""
+	code := ""
+	if t != nil {
+		code = t.ErrorCode
+		if code != "" || t.Status == TransactionStatusFailure || t.Status == TransactionStatusError {
+			failed = true
+			if message == "" {
+				message = t.Message
+			}
+		}
+	}
+	if !failed {
+		return nil
+	}
+	return &PaymentError{
+		Code:        code,
+		Message:     message,
+		Transaction: t,
+		err:         cardErrors[code],
+	}
+}
+
 // String returns the string value of the status.
 func (s TransactionStatus) String() string {
 	return transactionStatuses[s]
@@ -82,3 +205,119 @@ func (s *TransactionStatus) UnmarshalText(text []byte) error {
 	}
 	return fmt.Errorf("unknown transaction status %s", enum)
 }
+
+func (p *TransactionParam) EncodeQuery() string {
+	if p == nil {
+		return ""
+	}
+	v := url.Values{}
+	if p.SinceID > 0 {
+		v.Add("since_id", fmt.Sprintf("%d", p.SinceID))
+	}
+	if len(p.Fields) > 0 {
+		v.Add("fields", strings.Join(p.Fields, ","))
+	}
+	return encodeQuery(v, p.PageInfo)
+}
+
+func (s *TransactionService) List(ctx context.Context, orderID int64, params *TransactionParam) ([]*Transaction, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("/admin/orders/%d/transactions.json", orderID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.URL.RawQuery = params.EncodeQuery()
+
+	var transactionWrapper struct {
+		Transactions []*Transaction `json:"transactions"`
+	}
+	resp, err := s.client.Do(ctx, req, &transactionWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return transactionWrapper.Transactions, resp, nil
+}
+
+// TransactionIterator iterates over transactions, see Pagination.
+type TransactionIterator struct{ iterator }
+
+// Value returns the current transaction.
+func (it *TransactionIterator) Value() *Transaction {
+	v, _ := it.value().(*Transaction)
+	return v
+}
+
+// Iter returns an iterator over every transaction of the order.
+func (s *TransactionService) Iter(orderID int64, params *TransactionParam) *TransactionIterator {
+	var pp TransactionParam
+	if params != nil {
+		pp = *params
+	}
+	return &TransactionIterator{newIterator(func(ctx context.Context, pageInfo string) (interface{}, *http.Response, error) {
+		pp.PageInfo = pageInfo
+		return s.List(ctx, orderID, &pp)
+	})}
+}
+
+func (s *TransactionService) Count(ctx context.Context, orderID int64) (int, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("/admin/orders/%d/transactions/count.json", orderID), nil)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var transactionCount struct {
+		Count int `json:"count"`
+	}
+	resp, err := s.client.Do(ctx, req, &transactionCount)
+	if err != nil {
+		return 0, resp, err
+	}
+
+	return transactionCount.Count, resp, nil
+}
+
+func (s *TransactionService) Get(ctx context.Context, orderID, ID int64) (*Transaction, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("/admin/orders/%d/transactions/%d.json", orderID, ID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transactionWrapper := new(TransactionRequest)
+	resp, err := s.client.Do(ctx, req, transactionWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return transactionWrapper.Transaction, resp, nil
+}
+
+// Create creates a transaction of the given kind on the order, ie. a capture
+// of a previous authorization:
+//
+//	client.Transaction.Create(ctx, orderID, &shopify.Transaction{
+//		Kind:     shopify.TransactionKindCapture,
+//		ParentID: authorizationID,
+//		Amount:   "10.00",
+//		Currency: "USD",
+//	})
+func (s *TransactionService) Create(ctx context.Context, orderID int64, transaction *Transaction) (*Transaction, *http.Response, error) {
+	req, err := s.client.NewRequest(
+		"POST",
+		fmt.Sprintf("/admin/orders/%d/transactions.json", orderID),
+		&TransactionRequest{transaction},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transactionWrapper := new(TransactionRequest)
+	resp, err := s.client.Do(ctx, req, transactionWrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+	if err := transactionError(transactionWrapper.Transaction, ""); err != nil {
+		return transactionWrapper.Transaction, resp, err
+	}
+
+	return transactionWrapper.Transaction, resp, nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestTransactionError(t *testing.T) {
+	t.Parallel()
+
+	inputs := []struct {
+		name        string
+		transaction *Transaction
+		message     string
+		expected    error
+		failed      bool
+	}{
+		{
+			name:        "success",
+			transaction: &Transaction{Status: TransactionStatusSuccess, Message: "Bogus Gateway: Forced success"},
+		},
+		{
+			name:        "declined",
+			transaction: &Transaction{Status: TransactionStatusFailure, ErrorCode: "card_declined"},
+			expected:    ErrCardDeclined,
+			failed:      true,
+		},
+		{
+			name:        "invalid cvc",
+			transaction: &Transaction{Status: TransactionStatusFailure, ErrorCode: "invalid_cvc"},
+			expected:    ErrInvalidCvc,
+			failed:      true,
+		},
+		{
+			name:        "unknown code",
+			transaction: &Transaction{Status: TransactionStatusError, ErrorCode: "something_new"},
+			failed:      true,
+		},
+		{
+			name:    "processing error message",
+			message: "Your card was declined.",
+			failed:  true,
+		},
+	}
+
+	for _, tt := range inputs {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := transactionError(tt.transaction, tt.message)
+			if !tt.failed {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			var perr *PaymentError
+			if !errors.As(err, &perr) {
+				t.Fatalf("expected a *PaymentError, got %v", err)
+			}
+			if tt.expected != nil && !errors.Is(err, tt.expected) {
+				t.Errorf("expected errors.Is %v, got %v", tt.expected, err)
+			}
+			if tt.expected == nil && errors.Unwrap(err) != nil {
+				t.Errorf("expected no wrapped error, got %v", errors.Unwrap(err))
+			}
+		})
+	}
+}
+
+func TestTransactionCreateFailed(t *testing.T) {
+	t.Parallel()
+
+	c, _, teardown := setup(http.StatusCreated, `{"transaction":{"id":1,"kind":"capture","status":"failure","error_code":"expired_card","message":"Card expired"}}`)
+	defer teardown()
+
+	transaction, _, err := c.Transaction.Create(context.Background(), 1, &Transaction{Kind: TransactionKindCapture})
+	if !errors.Is(err, ErrExpiredCard) {
+		t.Fatalf("expected ErrExpiredCard, got %v", err)
+	}
+	if transaction == nil || transaction.ID != 1 {
+		t.Errorf("expected the failed transaction to be returned, got %+v", transaction)
+	}
+	if err.Error() != "payment failed: expired_card: Card expired" {
+		t.Errorf("unexpected error message %q", err.Error())
+	}
+}
+
+func TestTransactionService(t *testing.T) {
+	t.Parallel()
+
+	transaction := `{"id":2,"order_id":1,"kind":"capture","status":"success","amount":"10.00","parent_id":3,"gateway":"bogus"}`
+	inputs := []struct {
+		name     string
+		response string
+		call     func(c *Client) ([]*Transaction, int, error)
+		method   string
+		path     string
+		query    string
+	}{
+		{
+			name:     "list",
+			response: `{"transactions":[` + transaction + `]}`,
+			call: func(c *Client) ([]*Transaction, int, error) {
+				tt, _, err := c.Transaction.List(context.Background(), 1, &TransactionParam{SinceID: 1})
+				return tt, 0, err
+			},
+			method: "GET",
+			path:   "/admin/orders/1/transactions.json",
+			query:  "since_id=1",
+		},
+		{
+			name:     "get",
+			response: `{"transaction":` + transaction + `}`,
+			call: func(c *Client) ([]*Transaction, int, error) {
+				tr, _, err := c.Transaction.Get(context.Background(), 1, 2)
+				return []*Transaction{tr}, 0, err
+			},
+			method: "GET",
+			path:   "/admin/orders/1/transactions/2.json",
+		},
+		{
+			name:     "count",
+			response: `{"count":4}`,
+			call: func(c *Client) ([]*Transaction, int, error) {
+				count, _, err := c.Transaction.Count(context.Background(), 1)
+				return nil, count, err
+			},
+			method: "GET",
+			path:   "/admin/orders/1/transactions/count.json",
+		},
+		{
+			name:     "create",
+			response: `{"transaction":` + transaction + `}`,
+			call: func(c *Client) ([]*Transaction, int, error) {
+				tr, _, err := c.Transaction.Create(context.Background(), 1, &Transaction{
+					Kind:     TransactionKindCapture,
+					Amount:   "10.00",
+					ParentID: 3,
+				})
+				return []*Transaction{tr}, 0, err
+			},
+			method: "POST",
+			path:   "/admin/orders/1/transactions.json",
+		},
+	}
+
+	for _, tt := range inputs {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c, last, teardown := setup(http.StatusOK, tt.response)
+			defer teardown()
+
+			transactions, count, err := tt.call(c)
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			r := last()
+			if r.Method != tt.method || r.Path != tt.path || r.Query != tt.query {
+				t.Errorf("unexpected request %s %s?%s", r.Method, r.Path, r.Query)
+			}
+			if tt.method == "POST" {
+				expected := `{"transaction":{"kind":"capture","amount":"10.00","parent_id":3}}`
+				if body := strings.TrimSpace(r.Body); body != expected {
+					t.Errorf("expected body %s got %s", expected, body)
+				}
+			}
+			if tt.name == "count" {
+				if count != 4 {
+					t.Errorf("expected a count of 4, got %d", count)
+				}
+				return
+			}
+			if len(transactions) != 1 {
+				t.Fatalf("expected a transaction, got %v", transactions)
+			}
+			if tr := transactions[0]; tr.ID != 2 || tr.Status != TransactionStatusSuccess || tr.Amount != "10.00" || tr.ParentID != 3 {
+				t.Errorf("unexpected transaction %+v", tr)
+			}
+		})
+	}
+}
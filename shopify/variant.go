@@ -7,6 +7,7 @@ package shopify
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -37,15 +38,16 @@ type Variant struct {
 	InventoryQuantity    int     `json:"inventory_quantity"`
 	Weight               float64 `json:"weight"`
 	WeightUnit           string  `json:"weight_unit"`
-	InventoryItemID      int     `json:"inventory_item_id"`
+	InventoryItemID      int64   `json:"inventory_item_id"`
 	OldInventoryQuantity int     `json:"old_inventory_quantity"`
 	RequiresShipping     bool    `json:"requires_shipping"`
 	AdminGraphqlAPIID    string  `json:"admin_graphql_api_id"`
 }
 
 type VariantParam struct {
-	Limit int
-	Page  int
+	Limit    int
+	Page     int
+	PageInfo string
 }
 
 func (param *VariantParam) EncodeQuery() string {
@@ -55,11 +57,13 @@ func (param *VariantParam) EncodeQuery() string {
 	// for now just allow handle
 	// TODO: support all params
 	v := url.Values{}
-	v.Add("page", fmt.Sprintf("%d", param.Page))
 	if param.Limit > 0 {
 		v.Add("limit", fmt.Sprintf("%d", param.Limit))
 	}
-	return v.Encode()
+	if param.Page > 0 {
+		v.Add("page", fmt.Sprintf("%d", param.Page))
+	}
+	return encodeQuery(v, param.PageInfo)
 }
 
 func (p *VariantService) Get(ctx context.Context, params *VariantParam) ([]*Variant, *http.Response, error) {
@@ -81,6 +85,27 @@ func (p *VariantService) Get(ctx context.Context, params *VariantParam) ([]*Vari
 	return wrapper.Variants, resp, nil
 }
 
+// VariantIterator iterates over variants, see Pagination.
+type VariantIterator struct{ iterator }
+
+// Value returns the current variant.
+func (it *VariantIterator) Value() *Variant {
+	v, _ := it.value().(*Variant)
+	return v
+}
+
+// Iter returns an iterator over every variant.
+func (p *VariantService) Iter(params *VariantParam) *VariantIterator {
+	var pp VariantParam
+	if params != nil {
+		pp = *params
+	}
+	return &VariantIterator{newIterator(func(ctx context.Context, pageInfo string) (interface{}, *http.Response, error) {
+		pp.PageInfo = pageInfo
+		return p.Get(ctx, &pp)
+	})}
+}
+
 // fetch one product by the given product id
 func (p *VariantService) GetVariant(ctx context.Context, ID int64) (*Variant, *http.Response, error) {
 	req, err := p.client.NewRequest("GET", fmt.Sprintf("/admin/variants/%d.json", ID), nil)
@@ -98,3 +123,184 @@ func (p *VariantService) GetVariant(ctx context.Context, ID int64) (*Variant, *h
 
 	return wrapper.Variant, resp, nil
 }
+
+// VariantUpdate is a partial update of a variant. Only the fields that are
+// set are sent, use String, Int, Int64, Float64 and Bool to set them:
+//
+//	client.Variant.Update(ctx, &shopify.VariantUpdate{
+//		ID:    variantID,
+//		Price: shopify.String("19.99"),
+//	})
+//
+// The compare at price and inventory management can also be cleared:
+//
+//	client.Variant.Update(ctx, &shopify.VariantUpdate{
+//		ID:             variantID,
+//		CompareAtPrice: &shopify.NullString{},
+//	})
+//
+// Create takes a VariantUpdate as well, without ID.
+type VariantUpdate struct {
+	ID                  int64       `json:"id,omitempty"`
+	Title               *string     `json:"title,omitempty"`
+	Price               *string     `json:"price,omitempty"`
+	CompareAtPrice      *NullString `json:"compare_at_price,omitempty"`
+	Sku                 *string     `json:"sku,omitempty"`
+	Barcode             *string     `json:"barcode,omitempty"`
+	Position            *int        `json:"position,omitempty"`
+	InventoryPolicy     *string     `json:"inventory_policy,omitempty"`
+	FulfillmentService  *string     `json:"fulfillment_service,omitempty"`
+	InventoryManagement *NullString `json:"inventory_management,omitempty"`
+	Option1             *string     `json:"option1,omitempty"`
+	Option2             *string     `json:"option2,omitempty"`
+	Option3             *string     `json:"option3,omitempty"`
+	Taxable             *bool       `json:"taxable,omitempty"`
+	RequiresShipping    *bool       `json:"requires_shipping,omitempty"`
+	Grams               *int        `json:"grams,omitempty"`
+	Weight              *float64    `json:"weight,omitempty"`
+	WeightUnit          *string     `json:"weight_unit,omitempty"`
+	ImageID             *int64      `json:"image_id,omitempty"`
+}
+
+// NullString is a field of a partial update that can be cleared. It sends
+// String when Valid is set and null otherwise, use NullableString to set it.
+type NullString struct {
+	String string
+	Valid  bool
+}
+
+// NullableString returns a NullString of s, to set a field of a partial
+// update.
+func NullableString(s string) *NullString { return &NullString{String: s, Valid: true} }
+
+// MarshalJSON satisfies json.Marshaler.
+func (s NullString) MarshalJSON() ([]byte, error) {
+	if !s.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(s.String)
+}
+
+// String returns a pointer to s, to set a field of a partial update.
+func String(s string) *string { return &s }
+
+// Int returns a pointer to i, to set a field of a partial update.
+func Int(i int) *int { return &i }
+
+// Int64 returns a pointer to i, to set a field of a partial update.
+func Int64(i int64) *int64 { return &i }
+
+// Float64 returns a pointer to f, to set a field of a partial update.
+func Float64(f float64) *float64 { return &f }
+
+// Bool returns a pointer to b, to set a field of a partial update.
+func Bool(b bool) *bool { return &b }
+
+// Strings returns a pointer to a list of s, to set a field of a partial
+// update. Without arguments, it sets an empty list.
+func Strings(s ...string) *[]string {
+	if s == nil {
+		s = []string{}
+	}
+	return &s
+}
+
+// Int64s returns a pointer to a list of ids, to set a field of a partial
+// update. Without arguments, it sets an empty list.
+func Int64s(ids ...int64) *[]int64 {
+	if ids == nil {
+		ids = []int64{}
+	}
+	return &ids
+}
+
+// ListByProduct returns the variants of the product.
+func (p *VariantService) ListByProduct(ctx context.Context, productID int64, params *VariantParam) ([]*Variant, *http.Response, error) {
+	req, err := p.client.NewRequest("GET", fmt.Sprintf("/admin/products/%d/variants.json", productID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.URL.RawQuery = params.EncodeQuery()
+
+	var wrapper struct {
+		Variants []*Variant `json:"variants"`
+	}
+	resp, err := p.client.Do(ctx, req, &wrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return wrapper.Variants, resp, nil
+}
+
+// IterByProduct returns an iterator over every variant of the product.
+func (p *VariantService) IterByProduct(productID int64, params *VariantParam) *VariantIterator {
+	var pp VariantParam
+	if params != nil {
+		pp = *params
+	}
+	return &VariantIterator{newIterator(func(ctx context.Context, pageInfo string) (interface{}, *http.Response, error) {
+		pp.PageInfo = pageInfo
+		return p.ListByProduct(ctx, productID, &pp)
+	})}
+}
+
+// Count returns the number of variants of the product.
+func (p *VariantService) Count(ctx context.Context, productID int64) (int, *http.Response, error) {
+	req, err := p.client.NewRequest("GET", fmt.Sprintf("/admin/products/%d/variants/count.json", productID), nil)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var wrapper struct {
+		Count int `json:"count"`
+	}
+	resp, err := p.client.Do(ctx, req, &wrapper)
+	if err != nil {
+		return 0, resp, err
+	}
+
+	return wrapper.Count, resp, nil
+}
+
+// Create adds a variant to the product with the fields that are set, leaving
+// the others to Shopify's defaults. The variant must differ from the existing
+// ones by at least one option.
+func (p *VariantService) Create(ctx context.Context, productID int64, variant *VariantUpdate) (*Variant, *http.Response, error) {
+	return p.save(ctx, "POST", fmt.Sprintf("/admin/products/%d/variants.json", productID), variant)
+}
+
+// Update updates the fields of the variant that are set, leaving the others
+// untouched.
+func (p *VariantService) Update(ctx context.Context, variant *VariantUpdate) (*Variant, *http.Response, error) {
+	return p.save(ctx, "PUT", fmt.Sprintf("/admin/variants/%d.json", variant.ID), variant)
+}
+
+func (p *VariantService) save(ctx context.Context, method, path string, variant *VariantUpdate) (*Variant, *http.Response, error) {
+	body := struct {
+		Variant *VariantUpdate `json:"variant"`
+	}{variant}
+	req, err := p.client.NewRequest(method, path, &body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var wrapper struct {
+		Variant *Variant `json:"variant"`
+	}
+	resp, err := p.client.Do(ctx, req, &wrapper)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return wrapper.Variant, resp, nil
+}
+
+func (p *VariantService) Delete(ctx context.Context, productID, ID int64) (*http.Response, error) {
+	req, err := p.client.NewRequest("DELETE", fmt.Sprintf("/admin/products/%d/variants/%d.json", productID, ID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.client.Do(ctx, req, nil)
+}
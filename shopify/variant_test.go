@@ -0,0 +1,69 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestVariantUpdate(t *testing.T) {
+	t.Parallel()
+
+	c, last, teardown := setup(http.StatusOK, `{"variant":{"id":1,"price":"19.99","sku":"example-shirt-s","taxable":false}}`)
+	defer teardown()
+
+	variant, _, err := c.Variant.Update(context.Background(), &VariantUpdate{
+		ID:                  1,
+		Price:               String("19.99"),
+		CompareAtPrice:      &NullString{},
+		InventoryManagement: NullableString("shopify"),
+		Taxable:             Bool(false),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	r := last()
+	if r.Method != "PUT" || r.Path != "/admin/variants/1.json" {
+		t.Errorf("unexpected request %s %s", r.Method, r.Path)
+	}
+	// only the fields that are set are sent, explicit zero values and nulls
+	// included
+	expected := `{"variant":{"id":1,"price":"19.99","compare_at_price":null,` +
+		`"inventory_management":"shopify","taxable":false}}`
+	if body := strings.TrimSpace(r.Body); body != expected {
+		t.Errorf("expected body %s got %s", expected, body)
+	}
+	if variant.Sku != "example-shirt-s" {
+		t.Errorf("unexpected variant %+v", variant)
+	}
+}
+
+func TestVariantCreate(t *testing.T) {
+	t.Parallel()
+
+	c, last, teardown := setup(http.StatusCreated, `{"variant":{"id":2,"product_id":1,"option1":"Large","taxable":true}}`)
+	defer teardown()
+
+	variant, _, err := c.Variant.Create(context.Background(), 1, &VariantUpdate{Option1: String("Large"), Price: String("19.99")})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	r := last()
+	if r.Method != "POST" || r.Path != "/admin/products/1/variants.json" {
+		t.Errorf("unexpected request %s %s", r.Method, r.Path)
+	}
+	// unset flags are left to Shopify's defaults
+	expected := `{"variant":{"price":"19.99","option1":"Large"}}`
+	if body := strings.TrimSpace(r.Body); body != expected {
+		t.Errorf("expected body %s got %s", expected, body)
+	}
+	if variant.ID != 2 || !variant.Taxable {
+		t.Errorf("unexpected variant %+v", variant)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Shopify serves the admin API under dated versions, ie.
+// /admin/api/2019-07/products.json. Requests for an unsupported version are
+// silently served by the oldest supported one, which is reported back in the
+// API version response header.
+//
+// Shopify API docs: https://help.shopify.com/en/api/versioning
+const apiVersionHeader = `X-Shopify-API-Version`
+
+type apiVersionKey struct{}
+
+// APIVersion is an Option to set the admin API version, ie. "2019-07", used
+// by every request of the client. Without it, the unversioned paths are used.
+func APIVersion(version string) Option {
+	return func(o *Options) error {
+		o.APIVersion = version
+		return nil
+	}
+}
+
+// WithAPIVersion returns a copy of ctx that overrides the API version of the
+// requests sent with it. It can be used to migrate endpoints one at a time.
+func WithAPIVersion(ctx context.Context, version string) context.Context {
+	return context.WithValue(ctx, apiVersionKey{}, version)
+}
+
+// apiVersion returns the API version of requests sent with ctx.
+func (c *Client) apiVersion(ctx context.Context) string {
+	if v, ok := ctx.Value(apiVersionKey{}).(string); ok && v != "" {
+		return v
+	}
+	return c.opts.APIVersion
+}
+
+// checkAPIVersion warns, once per version, when Shopify served a different
+// API version than the requested one. See Log.
+func (c *Client) checkAPIVersion(requested string, resp *http.Response) {
+	served := resp.Header.Get(apiVersionHeader)
+	if requested == "" || served == "" || served == requested {
+		return
+	}
+	if _, warned := c.versionWarnings.LoadOrStore(requested+"/"+served, true); !warned {
+		c.logf("requested API version %s but was served %s", requested, served)
+	}
+}
+
+// versionedPath rewrites an admin path, versioned or not, to the given API
+// version. Paths outside of the versioned admin API are left untouched.
+func versionedPath(path, version string) string {
+	if version == "" || !strings.HasPrefix(path, "/admin/") {
+		return path
+	}
+	rest := strings.TrimPrefix(path, "/admin/")
+	if strings.HasPrefix(rest, "oauth/") {
+		return path
+	}
+	if strings.HasPrefix(rest, "api/") {
+		// already versioned, swap the version out
+		rest = strings.TrimPrefix(rest, "api/")
+		i := strings.Index(rest, "/")
+		if i < 0 {
+			return path
+		}
+		rest = rest[i+1:]
+	}
+	return "/admin/api/" + version + "/" + rest
+}
@@ -0,0 +1,87 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestVersionedPath(t *testing.T) {
+	t.Parallel()
+
+	inputs := []struct {
+		path     string
+		version  string
+		expected string
+	}{
+		{"/admin/products.json", "", "/admin/products.json"},
+		{"/admin/products.json", "2019-07", "/admin/api/2019-07/products.json"},
+		{"/admin/checkouts/abc/payments.json", "2019-07", "/admin/api/2019-07/checkouts/abc/payments.json"},
+		{"/admin/api/2019-04/products.json", "2019-07", "/admin/api/2019-07/products.json"},
+		{"/admin/oauth/access_token", "2019-07", "/admin/oauth/access_token"},
+		{"/sessions", "2019-07", "/sessions"},
+	}
+
+	for _, tt := range inputs {
+		if actual := versionedPath(tt.path, tt.version); actual != tt.expected {
+			t.Errorf("%s@%s: expected %s got %s", tt.path, tt.version, tt.expected, actual)
+		}
+	}
+}
+
+// logs collects the warnings of a client.
+type logs []string
+
+func (l *logs) Printf(format string, v ...interface{}) {
+	*l = append(*l, fmt.Sprintf(format, v...))
+}
+
+func TestAPIVersion(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		// shopify falls back to its oldest supported version
+		w.Header().Set(apiVersionHeader, "2019-04")
+		w.Write([]byte(`{"shop":{}}`))
+	}))
+	defer ts.Close()
+
+	var warnings logs
+	c, _ := NewClient(nil, ShopURL(ts.URL), APIVersion("2019-07"), Log(&warnings))
+
+	inputs := []struct {
+		ctx      context.Context
+		expected string
+	}{
+		{context.Background(), "/admin/api/2019-07/shop.json"},
+		{WithAPIVersion(context.Background(), "2019-10"), "/admin/api/2019-10/shop.json"},
+		// the mismatch of a version is only reported once
+		{context.Background(), "/admin/api/2019-07/shop.json"},
+	}
+
+	for i, tt := range inputs {
+		if _, _, err := c.Shop.Get(tt.ctx); err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if paths[i] != tt.expected {
+			t.Errorf("expected %s got %s", tt.expected, paths[i])
+		}
+	}
+
+	expected := logs{
+		"[shopify] requested API version 2019-07 but was served 2019-04",
+		"[shopify] requested API version 2019-10 but was served 2019-04",
+	}
+	if fmt.Sprint(warnings) != fmt.Sprint(expected) {
+		t.Errorf("expected warnings %q got %q", expected, warnings)
+	}
+}
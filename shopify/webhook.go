@@ -9,6 +9,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 )
 
@@ -88,6 +90,8 @@ const (
 	WebhookHeaderHmac       = "X-Shopify-Hmac-Sha256"
 	WebhookHeaderShopDomain = "X-Shopify-Shop-Domain"
 	WebhookHeaderTopic      = "X-Shopify-Topic"
+	WebhookHeaderWebhookID  = "X-Shopify-Webhook-Id"
+	WebhookHeaderAPIVersion = "X-Shopify-API-Version"
 )
 
 type Webhook struct {
@@ -107,11 +111,66 @@ type WebhookRequest struct {
 	Webhook *Webhook `json:"webhook"`
 }
 
+type WebhookListParam struct {
+	Address      string
+	Topic        Topic
+	CreatedAtMin *time.Time
+	CreatedAtMax *time.Time
+	UpdatedAtMin *time.Time
+	UpdatedAtMax *time.Time
+	Fields       []string
+	Limit        int
+	SinceID      int64
+	PageInfo     string
+}
+
+func (p *WebhookListParam) EncodeQuery() string {
+	if p == nil {
+		return ""
+	}
+	v := url.Values{}
+	if p.Address != "" {
+		v.Add("address", p.Address)
+	}
+	if p.Topic != TopicUnknown {
+		v.Add("topic", string(p.Topic))
+	}
+	if p.CreatedAtMin != nil {
+		v.Add("created_at_min", p.CreatedAtMin.Format(timeFormat))
+	}
+	if p.CreatedAtMax != nil {
+		v.Add("created_at_max", p.CreatedAtMax.Format(timeFormat))
+	}
+	if p.UpdatedAtMin != nil {
+		v.Add("updated_at_min", p.UpdatedAtMin.Format(timeFormat))
+	}
+	if p.UpdatedAtMax != nil {
+		v.Add("updated_at_max", p.UpdatedAtMax.Format(timeFormat))
+	}
+	if len(p.Fields) > 0 {
+		v.Add("fields", strings.Join(p.Fields, ","))
+	}
+	if p.Limit > 0 {
+		v.Add("limit", fmt.Sprintf("%d", p.Limit))
+	}
+	if p.SinceID > 0 {
+		v.Add("since_id", fmt.Sprintf("%d", p.SinceID))
+	}
+	return encodeQuery(v, p.PageInfo)
+}
+
 func (s *WebhookService) List(ctx context.Context) ([]*Webhook, *http.Response, error) {
+	return s.ListWithParams(ctx, nil)
+}
+
+// ListWithParams returns the webhooks matching params.
+func (s *WebhookService) ListWithParams(ctx context.Context, params *WebhookListParam) ([]*Webhook, *http.Response, error) {
 	req, err := s.client.NewRequest("GET", "/admin/webhooks.json", nil)
 	if err != nil {
 		return nil, nil, err
 	}
+	// encode param to query
+	req.URL.RawQuery = params.EncodeQuery()
 	var webhooksWrapper struct {
 		Webhooks []*Webhook `json:"webhooks"`
 	}
@@ -122,6 +181,58 @@ func (s *WebhookService) List(ctx context.Context) ([]*Webhook, *http.Response,
 	return webhooksWrapper.Webhooks, resp, nil
 }
 
+// WebhookIterator iterates over webhooks, see Pagination.
+type WebhookIterator struct{ iterator }
+
+// Value returns the current webhook.
+func (it *WebhookIterator) Value() *Webhook {
+	v, _ := it.value().(*Webhook)
+	return v
+}
+
+// Iter returns an iterator over every webhook matching params.
+func (s *WebhookService) Iter(params *WebhookListParam) *WebhookIterator {
+	var pp WebhookListParam
+	if params != nil {
+		pp = *params
+	}
+	return &WebhookIterator{newIterator(func(ctx context.Context, pageInfo string) (interface{}, *http.Response, error) {
+		pp.PageInfo = pageInfo
+		return s.ListWithParams(ctx, &pp)
+	})}
+}
+
+func (s *WebhookService) Count(ctx context.Context, params *WebhookListParam) (int, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "/admin/webhooks/count.json", nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	req.URL.RawQuery = params.EncodeQuery()
+
+	var webhookCount struct {
+		Count int `json:"count"`
+	}
+	resp, err := s.client.Do(ctx, req, &webhookCount)
+	if err != nil {
+		return 0, resp, err
+	}
+	return webhookCount.Count, resp, nil
+}
+
+func (s *WebhookService) Get(ctx context.Context, ID int64) (*Webhook, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", fmt.Sprintf("/admin/webhooks/%d.json", ID), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ww := new(WebhookRequest)
+	resp, err := s.client.Do(ctx, req, ww)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ww.Webhook, resp, nil
+}
+
 func (s *WebhookService) Create(ctx context.Context, webhook *WebhookRequest) (*Webhook, *http.Response, error) {
 	req, err := s.client.NewRequest("POST", "/admin/webhooks.json", webhook)
 	if err != nil {
@@ -136,6 +247,40 @@ func (s *WebhookService) Create(ctx context.Context, webhook *WebhookRequest) (*
 	return ww.Webhook, resp, nil
 }
 
+// WebhookUpdate is a partial update of a webhook subscription. Only the
+// fields that are set are sent, use String and Strings to set them:
+//
+//	client.Webhook.Update(ctx, &shopify.WebhookUpdate{
+//		ID:     webhookID,
+//		Fields: shopify.Strings(), // sends every field again
+//	})
+type WebhookUpdate struct {
+	ID                  int64     `json:"id"`
+	Address             *string   `json:"address,omitempty"`
+	Format              *string   `json:"format,omitempty"`
+	Fields              *[]string `json:"fields,omitempty"`
+	MetafieldNamespaces *[]string `json:"metafield_namespaces,omitempty"`
+}
+
+// Update changes the address, format, fields or metafield namespaces of an
+// existing subscription.
+func (s *WebhookService) Update(ctx context.Context, webhook *WebhookUpdate) (*Webhook, *http.Response, error) {
+	body := struct {
+		Webhook *WebhookUpdate `json:"webhook"`
+	}{webhook}
+	req, err := s.client.NewRequest("PUT", fmt.Sprintf("/admin/webhooks/%d.json", webhook.ID), &body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ww := new(WebhookRequest)
+	resp, err := s.client.Do(ctx, req, ww)
+	if err != nil {
+		return nil, resp, err
+	}
+	return ww.Webhook, resp, nil
+}
+
 func (s WebhookService) Delete(ctx context.Context, ID int64) (*http.Response, error) {
 	req, err := s.client.NewRequest("DELETE", fmt.Sprintf("/admin/webhooks/%d.json", ID), nil)
 	if err != nil {
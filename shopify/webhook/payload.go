@@ -0,0 +1,221 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/localyyz/go-shopify/shopify"
+)
+
+var ErrUnknownTopic = errors.New("webhook: no payload type registered for topic")
+
+// Deleted is the payload of the */delete topics, which only carry the id of
+// the deleted resource.
+type Deleted struct {
+	ID int64 `json:"id"`
+}
+
+type Cart struct {
+	ID        string          `json:"id"`
+	Token     string          `json:"token"`
+	Note      string          `json:"note"`
+	LineItems []*CartLineItem `json:"line_items"`
+
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+type CartLineItem struct {
+	ID        int64  `json:"id"`
+	VariantID int64  `json:"variant_id"`
+	ProductID int64  `json:"product_id"`
+	Title     string `json:"title"`
+	Quantity  int    `json:"quantity"`
+	Price     string `json:"price"`
+	LinePrice string `json:"line_price"`
+	Sku       string `json:"sku"`
+}
+
+// CustomerGroup is a saved customer search.
+type CustomerGroup struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Query string `json:"query"`
+
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+type DraftOrder struct {
+	ID            int64  `json:"id"`
+	OrderID       int64  `json:"order_id"`
+	Name          string `json:"name"`
+	Email         string `json:"email"`
+	Status        string `json:"status"`
+	InvoiceURL    string `json:"invoice_url"`
+	SubtotalPrice string `json:"subtotal_price"`
+	TotalTax      string `json:"total_tax"`
+	TotalPrice    string `json:"total_price"`
+	Currency      string `json:"currency"`
+
+	LineItems []*shopify.OrderLineItem `json:"line_items"`
+	Customer  *shopify.Customer        `json:"customer"`
+
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at"`
+}
+
+type Theme struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	Role         string `json:"role"`
+	Previewable  bool   `json:"previewable"`
+	Processing   bool   `json:"processing"`
+	ThemeStoreID int64  `json:"theme_store_id"`
+
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+// payload describes how to decode the payload of a topic.
+type payload struct {
+	new func() interface{}
+	// envelope is the key the payload is nested under, if any
+	envelope string
+}
+
+func newShop() interface{}             { return new(shopify.Shop) }
+func newCart() interface{}             { return new(Cart) }
+func newCheckout() interface{}         { return new(shopify.Checkout) }
+func newCollectionList() interface{}   { return new(shopify.CollectionList) }
+func newCollection() interface{}       { return new(shopify.CustomCollection) }
+func newCustomerGroup() interface{}    { return new(CustomerGroup) }
+func newCustomer() interface{}         { return new(shopify.Customer) }
+func newDraftOrder() interface{}       { return new(DraftOrder) }
+func newFulfillmentEvent() interface{} { return new(shopify.FulfillmentEvent) }
+func newFulfillment() interface{}      { return new(shopify.Fulfillment) }
+func newTransaction() interface{}      { return new(shopify.Transaction) }
+func newOrder() interface{}            { return new(shopify.Order) }
+func newProductList() interface{}      { return new(shopify.ProductList) }
+func newProduct() interface{}          { return new(shopify.Product) }
+func newRefund() interface{}           { return new(shopify.Refund) }
+func newTheme() interface{}            { return new(Theme) }
+func newDeleted() interface{}          { return new(Deleted) }
+
+var (
+	payloadsMu sync.RWMutex
+	payloads   = map[shopify.Topic]payload{
+		shopify.TopicAppUninstalled: {new: newShop},
+
+		shopify.TopicCartsCreate: {new: newCart},
+		shopify.TopicCartsUpdate: {new: newCart},
+
+		shopify.TopicCheckoutsCreate: {new: newCheckout},
+		shopify.TopicCheckoutsDelete: {new: newDeleted},
+		shopify.TopicCheckoutsUpdate: {new: newCheckout},
+
+		shopify.TopicCollectionListingsAdd:    {new: newCollectionList, envelope: "collection_listing"},
+		shopify.TopicCollectionListingsRemove: {new: newCollectionList, envelope: "collection_listing"},
+		shopify.TopicCollectionListingsUpdate: {new: newCollectionList, envelope: "collection_listing"},
+
+		shopify.TopicCollectionsCreate: {new: newCollection},
+		shopify.TopicCollectionsDelete: {new: newDeleted},
+		shopify.TopicCollectionsUpdate: {new: newCollection},
+
+		shopify.TopicCustomerGroupsCreate: {new: newCustomerGroup},
+		shopify.TopicCustomerGroupsDelete: {new: newDeleted},
+		shopify.TopicCustomerGroupsUpdate: {new: newCustomerGroup},
+
+		shopify.TopicCustomersCreate:  {new: newCustomer},
+		shopify.TopicCustomersDelete:  {new: newDeleted},
+		shopify.TopicCustomersDisable: {new: newCustomer},
+		shopify.TopicCustomersEnable:  {new: newCustomer},
+		shopify.TopicCustomersUpdate:  {new: newCustomer},
+
+		shopify.TopicDraftOrdersCreate: {new: newDraftOrder},
+		shopify.TopicDraftOrdersDelete: {new: newDeleted},
+		shopify.TopicDraftOrdersUpdate: {new: newDraftOrder},
+
+		shopify.TopicFulfillmentEventsCreate: {new: newFulfillmentEvent},
+		shopify.TopicFulfillmentEventsDelete: {new: newDeleted},
+		shopify.TopicFulfillmentsCreate:      {new: newFulfillment},
+		shopify.TopicFulfillmentsUpdate:      {new: newFulfillment},
+
+		shopify.TopicOrderTransactionsCreate:  {new: newTransaction},
+		shopify.TopicOrdersCancelled:          {new: newOrder},
+		shopify.TopicOrdersCreate:             {new: newOrder},
+		shopify.TopicOrdersDelete:             {new: newDeleted},
+		shopify.TopicOrdersFulfilled:          {new: newOrder},
+		shopify.TopicOrdersPaid:               {new: newOrder},
+		shopify.TopicOrdersPartiallyFulfilled: {new: newOrder},
+		shopify.TopicOrdersUpdated:            {new: newOrder},
+
+		shopify.TopicProductListingsAdd:    {new: newProductList, envelope: "product_listing"},
+		shopify.TopicProductListingsRemove: {new: newProductList, envelope: "product_listing"},
+		shopify.TopicProductListingsUpdate: {new: newProductList, envelope: "product_listing"},
+
+		shopify.TopicProductsCreate: {new: newProduct},
+		shopify.TopicProductsDelete: {new: newDeleted},
+		shopify.TopicProductsUpdate: {new: newProduct},
+
+		shopify.TopicRefundsCreate: {new: newRefund},
+
+		shopify.TopicShopUpdate: {new: newShop},
+
+		shopify.TopicThemesCreate:  {new: newTheme},
+		shopify.TopicThemesDelete:  {new: newTheme},
+		shopify.TopicThemesPublish: {new: newTheme},
+		shopify.TopicThemesUpdate:  {new: newTheme},
+	}
+)
+
+// Register sets the payload type of a topic, replacing the built-in one if
+// any. newPayload must return a pointer to a new value to decode into.
+func Register(topic shopify.Topic, newPayload func() interface{}) {
+	payloadsMu.Lock()
+	defer payloadsMu.Unlock()
+	payloads[topic] = payload{new: newPayload}
+}
+
+// Decode decodes the payload of the delivery into the type registered for its
+// topic, ie. a *shopify.Order for orders/create. The returned value is always
+// a pointer.
+func (d *Delivery) Decode() (interface{}, error) {
+	return Decode(d.Topic, d.Body)
+}
+
+// Decode decodes a raw payload of the given topic.
+func Decode(topic shopify.Topic, body []byte) (interface{}, error) {
+	payloadsMu.RLock()
+	p, ok := payloads[topic]
+	payloadsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTopic, topic)
+	}
+
+	v := p.new()
+	if p.envelope == "" {
+		if err := json.Unmarshal(body, v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(envelope[p.envelope], v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
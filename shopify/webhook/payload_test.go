@@ -0,0 +1,88 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/localyyz/go-shopify/shopify"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// TestDecode decodes the sample payload of each fixture and compares it,
+// re-encoded, with its golden file. A change in the golden output means a
+// payload field is no longer decoded as before. Run with -update to accept
+// the changes.
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	inputs := []struct {
+		fixture  string
+		topic    shopify.Topic
+		expected string
+	}{
+		{"app_uninstalled", shopify.TopicAppUninstalled, "*shopify.Shop"},
+		{"checkouts_update", shopify.TopicCheckoutsUpdate, "*shopify.Checkout"},
+		{"collections_update", shopify.TopicCollectionsUpdate, "*shopify.CustomCollection"},
+		{"customers_create", shopify.TopicCustomersCreate, "*shopify.Customer"},
+		{"fulfillments_create", shopify.TopicFulfillmentsCreate, "*shopify.Fulfillment"},
+		{"order_transactions_create", shopify.TopicOrderTransactionsCreate, "*shopify.Transaction"},
+		{"orders_create", shopify.TopicOrdersCreate, "*shopify.Order"},
+		{"product_listings_add", shopify.TopicProductListingsAdd, "*shopify.ProductList"},
+		{"products_delete", shopify.TopicProductsDelete, "*webhook.Deleted"},
+		{"products_update", shopify.TopicProductsUpdate, "*shopify.Product"},
+		{"refunds_create", shopify.TopicRefundsCreate, "*shopify.Refund"},
+		{"themes_publish", shopify.TopicThemesPublish, "*webhook.Theme"},
+	}
+
+	for _, tt := range inputs {
+		body, err := ioutil.ReadFile(filepath.Join("testdata", tt.fixture+".json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		d := &Delivery{Topic: tt.topic, Body: body}
+		v, err := d.Decode()
+		if err != nil {
+			t.Errorf("%s: %v", tt.fixture, err)
+			continue
+		}
+		if actual := fmt.Sprintf("%T", v); actual != tt.expected {
+			t.Errorf("%s: expected %s got %s", tt.fixture, tt.expected, actual)
+		}
+
+		actual, _ := json.MarshalIndent(v, "", "  ")
+		golden := filepath.Join("testdata", "golden", tt.fixture+".json")
+		if *update {
+			if err := ioutil.WriteFile(golden, append(actual, '\n'), 0644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		expected, err := ioutil.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(bytes.TrimSpace(expected), actual) {
+			t.Errorf("%s: decoded payload does not match %s\n%s", tt.fixture, golden, actual)
+		}
+	}
+}
+
+func TestDecodeUnknownTopic(t *testing.T) {
+	t.Parallel()
+
+	if _, err := Decode(shopify.Topic("unknown/topic"), []byte(`{}`)); err == nil {
+		t.Error("expected an error for an unknown topic")
+	}
+}
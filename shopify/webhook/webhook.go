@@ -0,0 +1,161 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package webhook receives webhooks delivered by Shopify.
+//
+// Every delivery is signed with the app's shared secret. Handler verifies the
+// signature before dispatching the delivery to the callback registered for its
+// topic:
+//
+//	h := webhook.NewHandler(os.Getenv("SHOPIFY_APP_SECRET"))
+//	h.HandleFunc(shopify.TopicAppUninstalled, func(ctx context.Context, d *webhook.Delivery) error {
+//		return uninstall(ctx, d.ShopDomain)
+//	})
+//	http.Handle("/webhooks", h)
+//
+// Shopify API docs: https://help.shopify.com/en/api/reference/events/webhook
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"github.com/localyyz/go-shopify/shopify"
+)
+
+// maxBodySize caps the size of a delivery read into memory.
+const maxBodySize = 10 << 20
+
+var (
+	ErrInvalidSignature = errors.New("webhook: invalid signature")
+	ErrMissingTopic     = errors.New("webhook: missing topic")
+)
+
+// Delivery is a single webhook delivered by Shopify.
+type Delivery struct {
+	Topic      shopify.Topic
+	ShopDomain string
+	WebhookID  string
+	APIVersion string
+
+	// Body is the raw JSON payload
+	Body []byte
+}
+
+// HandlerFunc handles a verified delivery. Returning an error responds with
+// 500 Internal Server Error, and Shopify will retry the delivery later.
+type HandlerFunc func(ctx context.Context, d *Delivery) error
+
+// Handler is an http.Handler that verifies webhook deliveries and dispatches
+// them to the callback registered for their topic. Deliveries of topics
+// without a callback are acknowledged and dropped.
+type Handler struct {
+	secret []byte
+
+	mu     sync.RWMutex
+	routes map[shopify.Topic]HandlerFunc
+}
+
+// NewHandler returns a Handler verifying deliveries with the app's shared
+// secret.
+func NewHandler(secret string) *Handler {
+	return &Handler{
+		secret: []byte(secret),
+		routes: make(map[shopify.Topic]HandlerFunc),
+	}
+}
+
+// HandleFunc registers the callback for the given topic, replacing any
+// previously registered one.
+func (h *Handler) HandleFunc(topic shopify.Topic, fn HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.routes[topic] = fn
+}
+
+// ServeHTTP satisfies http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	d, err := h.parse(r)
+	switch err {
+	case nil:
+	case ErrInvalidSignature:
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.mu.RLock()
+	fn := h.routes[d.Topic]
+	h.mu.RUnlock()
+
+	if fn != nil {
+		if err := fn(r.Context(), d); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// Parse reads and verifies a delivery from an incoming request.
+func Parse(r *http.Request, secret string) (*Delivery, error) {
+	return NewHandler(secret).parse(r)
+}
+
+func (h *Handler) parse(r *http.Request) (*Delivery, error) {
+	body, err := ioutil.ReadAll(http.MaxBytesReader(nil, r.Body, maxBodySize))
+	if err != nil {
+		return nil, err
+	}
+	if !verify(h.secret, body, r.Header.Get(shopify.WebhookHeaderHmac)) {
+		return nil, ErrInvalidSignature
+	}
+
+	d := &Delivery{
+		Topic:      shopify.Topic(r.Header.Get(shopify.WebhookHeaderTopic)),
+		ShopDomain: r.Header.Get(shopify.WebhookHeaderShopDomain),
+		WebhookID:  r.Header.Get(shopify.WebhookHeaderWebhookID),
+		APIVersion: r.Header.Get(shopify.WebhookHeaderAPIVersion),
+		Body:       body,
+	}
+	if d.Topic == shopify.TopicUnknown {
+		return nil, ErrMissingTopic
+	}
+	return d, nil
+}
+
+// Verify reports whether signature, the base64 encoded value of the HMAC
+// header, is the SHA256 HMAC of body with the app's shared secret. The
+// comparison is done in constant time.
+func Verify(secret string, body []byte, signature string) bool {
+	return verify([]byte(secret), body, signature)
+}
+
+func verify(secret, body []byte, signature string) bool {
+	if len(secret) == 0 || signature == "" {
+		return false
+	}
+	actual, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(actual, mac.Sum(nil))
+}
@@ -0,0 +1,83 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/localyyz/go-shopify/shopify"
+)
+
+const testSecret = "hush"
+
+func sign(body string) string {
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write([]byte(body))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func newDelivery(topic shopify.Topic, body, signature string) *http.Request {
+	r := httptest.NewRequest("POST", "/webhooks", strings.NewReader(body))
+	r.Header.Set(shopify.WebhookHeaderHmac, signature)
+	r.Header.Set(shopify.WebhookHeaderTopic, string(topic))
+	r.Header.Set(shopify.WebhookHeaderShopDomain, "x.myshopify.com")
+	r.Header.Set(shopify.WebhookHeaderWebhookID, "b54557e4-bdd9-4b37-8a5f-bf7d70bcd043")
+	r.Header.Set(shopify.WebhookHeaderAPIVersion, "2019-07")
+	return r
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	var received *Delivery
+	h := NewHandler(testSecret)
+	h.HandleFunc(shopify.TopicAppUninstalled, func(ctx context.Context, d *Delivery) error {
+		received = d
+		return nil
+	})
+	h.HandleFunc(shopify.TopicShopUpdate, func(ctx context.Context, d *Delivery) error {
+		return errors.New("boom")
+	})
+
+	body := `{"id":1}`
+	inputs := []struct {
+		name     string
+		req      *http.Request
+		expected int
+	}{
+		{"valid", newDelivery(shopify.TopicAppUninstalled, body, sign(body)), http.StatusOK},
+		{"bad signature", newDelivery(shopify.TopicAppUninstalled, body, sign("{}")), http.StatusUnauthorized},
+		{"missing signature", newDelivery(shopify.TopicAppUninstalled, body, ""), http.StatusUnauthorized},
+		{"unrouted topic", newDelivery(shopify.TopicProductsCreate, body, sign(body)), http.StatusOK},
+		{"callback error", newDelivery(shopify.TopicShopUpdate, body, sign(body)), http.StatusInternalServerError},
+		{"missing topic", newDelivery(shopify.TopicUnknown, body, sign(body)), http.StatusBadRequest},
+		{"wrong method", httptest.NewRequest("GET", "/webhooks", nil), http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range inputs {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, tt.req)
+		if w.Code != tt.expected {
+			t.Errorf("%s: expected %d got %d", tt.name, tt.expected, w.Code)
+		}
+	}
+
+	if received == nil {
+		t.Fatal("expected app/uninstalled to be dispatched")
+	}
+	if received.ShopDomain != "x.myshopify.com" || received.APIVersion != "2019-07" ||
+		received.WebhookID == "" || string(received.Body) != body {
+		t.Errorf("unexpected delivery %+v", received)
+	}
+}
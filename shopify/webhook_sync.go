@@ -0,0 +1,167 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"sort"
+)
+
+// WebhookSyncReport lists the changes needed, or made, to bring the webhook
+// subscriptions of a shop to the desired state.
+type WebhookSyncReport struct {
+	Created   []*Webhook
+	Updated   []*Webhook
+	Deleted   []*Webhook
+	Unchanged []*Webhook
+}
+
+// HasChanges reports whether the report holds any change.
+func (r *WebhookSyncReport) HasChanges() bool {
+	return len(r.Created)+len(r.Updated)+len(r.Deleted) > 0
+}
+
+// Plan is the dry-run mode of Sync: it diffs the desired subscriptions against
+// the existing ones and returns the changes Sync would make, without making
+// them.
+func (s *WebhookService) Plan(ctx context.Context, desired []*Webhook) (*WebhookSyncReport, error) {
+	var existing []*Webhook
+	// fetch the largest pages shopify allows
+	it := s.Iter(&WebhookListParam{Limit: 250})
+	for it.Next(ctx) {
+		existing = append(existing, it.Value())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return planWebhooks(existing, desired), nil
+}
+
+// Sync reconciles the webhook subscriptions of the shop with the desired ones.
+// Subscriptions are matched by topic and address: missing ones are created,
+// ones with different fields, metafield namespaces or format are updated, and
+// the ones that aren't desired are deleted.
+//
+// The returned report lists the changes made. If an error occurs, the changes
+// made so far are returned along with it.
+func (s *WebhookService) Sync(ctx context.Context, desired []*Webhook) (*WebhookSyncReport, error) {
+	plan, err := s.Plan(ctx, desired)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &WebhookSyncReport{Unchanged: plan.Unchanged}
+	for _, w := range plan.Created {
+		created, _, err := s.Create(ctx, &WebhookRequest{w})
+		if err != nil {
+			return report, err
+		}
+		report.Created = append(report.Created, created)
+	}
+	for _, w := range plan.Updated {
+		// empty fields and metafield namespaces are sent to clear them
+		updated, _, err := s.Update(ctx, &WebhookUpdate{
+			ID:                  int64(w.ID),
+			Format:              String(webhookFormat(w)),
+			Fields:              Strings(w.Fields...),
+			MetafieldNamespaces: Strings(w.MetafieldNamespaces...),
+		})
+		if err != nil {
+			return report, err
+		}
+		report.Updated = append(report.Updated, updated)
+	}
+	for _, w := range plan.Deleted {
+		if _, err := s.Delete(ctx, int64(w.ID)); err != nil {
+			return report, err
+		}
+		report.Deleted = append(report.Deleted, w)
+	}
+	return report, nil
+}
+
+type webhookKey struct {
+	topic   Topic
+	address string
+}
+
+func planWebhooks(existing, desired []*Webhook) *WebhookSyncReport {
+	current := make(map[webhookKey]*Webhook, len(existing))
+	report := &WebhookSyncReport{}
+	for _, w := range existing {
+		k := webhookKey{w.Topic, w.Address}
+		if _, ok := current[k]; ok {
+			// duplicate subscription
+			report.Deleted = append(report.Deleted, w)
+			continue
+		}
+		current[k] = w
+	}
+
+	wanted := make(map[webhookKey]bool, len(desired))
+	for _, w := range desired {
+		k := webhookKey{w.Topic, w.Address}
+		if wanted[k] {
+			continue
+		}
+		wanted[k] = true
+
+		e, ok := current[k]
+		if !ok {
+			report.Created = append(report.Created, w)
+			continue
+		}
+		if webhookChanged(e, w) {
+			ww := *w
+			ww.ID = e.ID
+			report.Updated = append(report.Updated, &ww)
+			continue
+		}
+		report.Unchanged = append(report.Unchanged, e)
+	}
+
+	for _, w := range existing {
+		if !wanted[webhookKey{w.Topic, w.Address}] && current[webhookKey{w.Topic, w.Address}] == w {
+			report.Deleted = append(report.Deleted, w)
+		}
+	}
+	return report
+}
+
+// webhookChanged reports whether the existing subscription differs from the
+// desired one in any of the fields that can be updated.
+func webhookChanged(existing, desired *Webhook) bool {
+	if webhookFormat(existing) != webhookFormat(desired) {
+		return true
+	}
+	return !sameStrings(existing.Fields, desired.Fields) ||
+		!sameStrings(existing.MetafieldNamespaces, desired.MetafieldNamespaces)
+}
+
+func webhookFormat(w *Webhook) string {
+	if w.Format == "" {
+		// shopify defaults to json
+		return "json"
+	}
+	return w.Format
+}
+
+// sameStrings reports whether a and b hold the same strings, in any order.
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	aa := append([]string(nil), a...)
+	bb := append([]string(nil), b...)
+	sort.Strings(aa)
+	sort.Strings(bb)
+	for i := range aa {
+		if aa[i] != bb[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,114 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestPlanWebhooks(t *testing.T) {
+	t.Parallel()
+
+	existing := []*Webhook{
+		{ID: 1, Topic: TopicOrdersCreate, Address: "https://a/orders", Format: "json"},
+		{ID: 2, Topic: TopicProductsUpdate, Address: "https://a/products", Format: "json", Fields: []string{"id", "title"}},
+		{ID: 3, Topic: TopicAppUninstalled, Address: "https://old/uninstall", Format: "json"},
+		{ID: 4, Topic: TopicOrdersCreate, Address: "https://a/orders", Format: "json"},
+	}
+	desired := []*Webhook{
+		{Topic: TopicOrdersCreate, Address: "https://a/orders"},
+		{Topic: TopicProductsUpdate, Address: "https://a/products", Fields: []string{"title", "id", "handle"}},
+		{Topic: TopicAppUninstalled, Address: "https://new/uninstall"},
+	}
+
+	report := planWebhooks(existing, desired)
+
+	ids := func(ww []*Webhook) []int {
+		var ids []int
+		for _, w := range ww {
+			ids = append(ids, w.ID)
+		}
+		return ids
+	}
+	if len(report.Created) != 1 || report.Created[0].Address != "https://new/uninstall" {
+		t.Errorf("expected new uninstall address to be created, got %+v", report.Created)
+	}
+	if u := ids(report.Updated); len(u) != 1 || u[0] != 2 {
+		t.Errorf("expected webhook 2 to be updated, got %v", u)
+	}
+	if d := ids(report.Deleted); len(d) != 2 || d[0] != 4 || d[1] != 3 {
+		t.Errorf("expected duplicate 4 and stale 3 to be deleted, got %v", d)
+	}
+	if u := ids(report.Unchanged); len(u) != 1 || u[0] != 1 {
+		t.Errorf("expected webhook 1 to be unchanged, got %v", u)
+	}
+	if !report.HasChanges() {
+		t.Error("expected changes")
+	}
+	if existing[1].Fields[0] != "id" {
+		t.Error("expected existing webhooks to be left untouched")
+	}
+}
+
+func TestWebhookSync(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, strings.TrimSpace(r.Method+" "+r.URL.Path+" "+string(b)))
+		mu.Unlock()
+
+		switch {
+		case r.Method == "GET" && r.URL.Query().Get("page_info") == "":
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s/admin/webhooks.json?page_info=p2>; rel="next"`, r.Host))
+			w.Write([]byte(`{"webhooks":[{"id":1,"topic":"orders/create","address":"https://a/orders","format":"json","fields":["id"]}]}`))
+		case r.Method == "GET":
+			w.Write([]byte(`{"webhooks":[{"id":2,"topic":"app/uninstalled","address":"https://old/uninstall","format":"json"}]}`))
+		case r.Method == "DELETE":
+			w.Write([]byte(`{}`))
+		default:
+			w.Write([]byte(`{"webhook":{"id":3}}`))
+		}
+	}))
+	defer ts.Close()
+
+	c, _ := NewClient(nil, ShopURL(ts.URL))
+	report, err := c.Webhook.Sync(context.Background(), []*Webhook{
+		{Topic: TopicOrdersCreate, Address: "https://a/orders"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(report.Updated) != 1 || len(report.Deleted) != 1 || len(report.Created) != 0 {
+		t.Errorf("unexpected report %+v", report)
+	}
+
+	expected := []string{
+		"GET /admin/webhooks.json",
+		"GET /admin/webhooks.json",
+		// the fields of the subscription are cleared
+		`PUT /admin/webhooks/1.json {"webhook":{"id":1,"format":"json","fields":[],"metafield_namespaces":[]}}`,
+		// the subscription on the second page is deleted
+		"DELETE /admin/webhooks/2.json",
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if strings.Join(requests, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected requests\n%s\ngot\n%s", strings.Join(expected, "\n"), strings.Join(requests, "\n"))
+	}
+}
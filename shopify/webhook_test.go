@@ -0,0 +1,145 @@
+// Copyright 2019 The go-shopify AUTHORS. All rights reserved.
+//
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package shopify
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhookListParam(t *testing.T) {
+	t.Parallel()
+
+	since := time.Date(2019, 3, 29, 13, 2, 8, 0, time.UTC)
+	inputs := []struct {
+		name     string
+		params   *WebhookListParam
+		expected string
+	}{
+		{"nil", nil, ""},
+		{
+			"filters",
+			&WebhookListParam{
+				Address:      "https://x/orders",
+				Topic:        TopicOrdersCreate,
+				CreatedAtMin: &since,
+				UpdatedAtMax: &since,
+				Fields:       []string{"id", "address"},
+				Limit:        50,
+				SinceID:      2,
+			},
+			"address=https%3A%2F%2Fx%2Forders&created_at_min=2019-03-29T13%3A02%3A08%2B00%3A00&fields=id%2Caddress" +
+				"&limit=50&since_id=2&topic=orders%2Fcreate&updated_at_max=2019-03-29T13%3A02%3A08%2B00%3A00",
+		},
+		{
+			"cursor",
+			&WebhookListParam{Topic: TopicOrdersCreate, Limit: 50, PageInfo: "abc"},
+			"limit=50&page_info=abc",
+		},
+	}
+
+	for _, tt := range inputs {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if q := tt.params.EncodeQuery(); q != tt.expected {
+				t.Errorf("expected %q got %q", tt.expected, q)
+			}
+		})
+	}
+}
+
+func TestWebhookGet(t *testing.T) {
+	t.Parallel()
+
+	c, last, teardown := setup(http.StatusOK, `{"webhook":{"id":1,"topic":"orders/create","address":"https://x/orders","format":"json"}}`)
+	defer teardown()
+
+	webhook, _, err := c.Webhook.Get(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if r := last(); r.Method != "GET" || r.Path != "/admin/webhooks/1.json" {
+		t.Errorf("unexpected request %s %s", r.Method, r.Path)
+	}
+	if webhook.ID != 1 || webhook.Topic != TopicOrdersCreate || webhook.Address != "https://x/orders" {
+		t.Errorf("unexpected webhook %+v", webhook)
+	}
+}
+
+func TestWebhookCount(t *testing.T) {
+	t.Parallel()
+
+	c, last, teardown := setup(http.StatusOK, `{"count":3}`)
+	defer teardown()
+
+	count, _, err := c.Webhook.Count(context.Background(), &WebhookListParam{Topic: TopicOrdersCreate})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if r := last(); r.Path != "/admin/webhooks/count.json" || r.Query != "topic=orders%2Fcreate" {
+		t.Errorf("unexpected request %s?%s", r.Path, r.Query)
+	}
+	if count != 3 {
+		t.Errorf("expected a count of 3, got %d", count)
+	}
+}
+
+func TestWebhookList(t *testing.T) {
+	t.Parallel()
+
+	c, last, teardown := setup(http.StatusOK, `{"webhooks":[{"id":1,"topic":"orders/create"}]}`)
+	defer teardown()
+
+	webhooks, _, err := c.Webhook.List(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if r := last(); r.Path != "/admin/webhooks.json" || r.Query != "" {
+		t.Errorf("unexpected request %s?%s", r.Path, r.Query)
+	}
+	if len(webhooks) != 1 || webhooks[0].ID != 1 {
+		t.Errorf("unexpected webhooks %+v", webhooks)
+	}
+
+	if _, _, err := c.Webhook.ListWithParams(context.Background(), &WebhookListParam{Topic: TopicOrdersCreate}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if r := last(); r.Path != "/admin/webhooks.json" || r.Query != "topic=orders%2Fcreate" {
+		t.Errorf("unexpected request %s?%s", r.Path, r.Query)
+	}
+}
+
+func TestWebhookUpdate(t *testing.T) {
+	t.Parallel()
+
+	c, last, teardown := setup(http.StatusOK, `{"webhook":{"id":1,"address":"https://x/orders"}}`)
+	defer teardown()
+
+	webhook, _, err := c.Webhook.Update(context.Background(), &WebhookUpdate{
+		ID:      1,
+		Address: String("https://x/orders"),
+		Fields:  Strings(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	r := last()
+	if r.Method != "PUT" || r.Path != "/admin/webhooks/1.json" {
+		t.Errorf("unexpected request %s %s", r.Method, r.Path)
+	}
+	// only the fields that are set are sent, empty lists included
+	expected := `{"webhook":{"id":1,"address":"https://x/orders","fields":[]}}`
+	if body := strings.TrimSpace(r.Body); body != expected {
+		t.Errorf("expected body %s got %s", expected, body)
+	}
+	if webhook.ID != 1 {
+		t.Errorf("unexpected webhook %+v", webhook)
+	}
+}